@@ -4,4 +4,9 @@ provider "clickhouse" {
   port     = 8123
   username = "default"
   password = "mysecretpassword"
+
+  retry = {
+    max_attempts    = 5
+    initial_backoff = "500ms"
+  }
 }
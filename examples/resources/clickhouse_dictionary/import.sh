@@ -0,0 +1,3 @@
+# Dictionary can be imported by specifying database and dictionary name.
+# Source, attribute defaults and flags are not exposed by ClickHouse and should be set in the configuration
+terraform import clickhouse_dictionary.countries default.countries
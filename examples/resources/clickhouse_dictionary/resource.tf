@@ -0,0 +1,57 @@
+resource "clickhouse_table" "countries" {
+  database = "default"
+  name     = "countries"
+  engine   = "MergeTree"
+  order_by = ["id"]
+
+  columns = [
+    {
+      name = "id"
+      type = "UInt64"
+    },
+    {
+      name = "parent_id"
+      type = "UInt64"
+    },
+    {
+      name = "name"
+      type = "String"
+    },
+  ]
+}
+
+resource "clickhouse_dictionary" "countries" {
+  database    = "default"
+  name        = "countries_dict"
+  primary_key = ["id"]
+
+  attributes = [
+    {
+      name = "id"
+      type = "UInt64"
+    },
+    {
+      name         = "parent_id"
+      type         = "UInt64"
+      default      = "0"
+      hierarchical = true
+    },
+    {
+      name      = "name"
+      type      = "String"
+      default   = "''"
+      injective = true
+    },
+  ]
+
+  source = {
+    clickhouse = {
+      db    = clickhouse_table.countries.database
+      table = clickhouse_table.countries.name
+    }
+  }
+
+  layout       = "HASHED"
+  lifetime_min = 300
+  lifetime_max = 600
+}
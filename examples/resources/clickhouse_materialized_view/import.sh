@@ -0,0 +1,2 @@
+# Materialized view can be imported by specifying database and view name
+terraform import clickhouse_materialized_view.my_view default.my_view
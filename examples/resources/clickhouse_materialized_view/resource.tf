@@ -0,0 +1,53 @@
+resource "clickhouse_table" "events" {
+  database = "default"
+  name     = "events"
+  engine   = "MergeTree"
+  order_by = ["ts"]
+
+  columns = [
+    {
+      name = "ts"
+      type = "DateTime"
+    },
+    {
+      name = "user_id"
+      type = "UInt64"
+    },
+  ]
+}
+
+resource "clickhouse_table" "events_by_user" {
+  database = "default"
+  name     = "events_by_user"
+  engine   = "SummingMergeTree"
+  order_by = ["user_id"]
+
+  columns = [
+    {
+      name = "user_id"
+      type = "UInt64"
+    },
+    {
+      name = "events"
+      type = "UInt64"
+    },
+  ]
+}
+
+# Materialized view writing to an existing table
+resource "clickhouse_materialized_view" "events_by_user" {
+  database = "default"
+  name     = "events_by_user_mv"
+  to_table = clickhouse_table.events_by_user.full_name
+  query    = "SELECT user_id, count() AS events FROM ${clickhouse_table.events.full_name} GROUP BY user_id"
+}
+
+# Materialized view storing data in its inner table
+resource "clickhouse_materialized_view" "events_by_day" {
+  database = "default"
+  name     = "events_by_day_mv"
+  engine   = "SummingMergeTree"
+  order_by = ["day"]
+  populate = true
+  query    = "SELECT toDate(ts) AS day, count() AS events FROM ${clickhouse_table.events.full_name} GROUP BY day"
+}
@@ -0,0 +1,2 @@
+# Quota can be imported by specifying its name
+terraform import clickhouse_quota.analysts analysts
@@ -0,0 +1,27 @@
+resource "clickhouse_role" "analysts" {
+  name = "analysts"
+}
+
+resource "clickhouse_quota" "analysts" {
+  name     = "analysts"
+  keyed_by = "user_name"
+
+  intervals = [
+    {
+      duration           = "1 hour"
+      max_queries        = 1000
+      max_read_bytes     = 100000000000
+      max_execution_time = 600
+    },
+    {
+      duration    = "1 day"
+      randomized  = true
+      max_errors  = 100
+      max_queries = 10000
+    },
+  ]
+
+  apply_to = {
+    names = [clickhouse_role.analysts.name]
+  }
+}
@@ -0,0 +1,2 @@
+# Row policy can be imported by specifying its name, database and table
+terraform import clickhouse_row_policy.tenant 'tenant ON default.events'
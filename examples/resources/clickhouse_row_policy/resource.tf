@@ -0,0 +1,51 @@
+resource "clickhouse_table" "events" {
+  database = "default"
+  name     = "events"
+  engine   = "MergeTree"
+  order_by = ["tenant_id"]
+
+  columns = [
+    {
+      name = "tenant_id"
+      type = "UInt64"
+    },
+    {
+      name = "payload"
+      type = "String"
+    },
+  ]
+}
+
+resource "clickhouse_role" "tenant_1" {
+  name = "tenant_1"
+}
+
+resource "clickhouse_role" "admin" {
+  name = "admin"
+}
+
+# Tenant sees only its own rows
+resource "clickhouse_row_policy" "tenant_1" {
+  name     = "tenant_1"
+  database = clickhouse_table.events.database
+  table    = clickhouse_table.events.name
+  using    = "tenant_id = 1"
+
+  apply_to = {
+    names = [clickhouse_role.tenant_1.name]
+  }
+}
+
+# Everybody except admins sees no rows of other tables in the database
+resource "clickhouse_row_policy" "deny_all" {
+  name     = "deny_all"
+  database = "default"
+  table    = "*"
+  using    = "0"
+  as       = "RESTRICTIVE"
+
+  apply_to = {
+    all    = true
+    except = [clickhouse_role.admin.name]
+  }
+}
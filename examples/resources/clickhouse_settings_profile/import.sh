@@ -0,0 +1,2 @@
+# Settings profile can be imported by specifying its name
+terraform import clickhouse_settings_profile.limited limited
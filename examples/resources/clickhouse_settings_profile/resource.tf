@@ -0,0 +1,25 @@
+resource "clickhouse_role" "analysts" {
+  name = "analysts"
+}
+
+resource "clickhouse_settings_profile" "limited" {
+  name    = "limited"
+  inherit = ["default"]
+
+  settings = [
+    {
+      name  = "max_memory_usage"
+      value = "10000000000"
+      max   = "20000000000"
+    },
+    {
+      name        = "readonly"
+      value       = "1"
+      writability = "CONST"
+    },
+  ]
+
+  apply_to = {
+    names = [clickhouse_role.analysts.name]
+  }
+}
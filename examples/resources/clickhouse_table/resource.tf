@@ -29,6 +29,38 @@ resource "clickhouse_table" "my_table" {
       name     = "value"
       type     = "Float64"
       nullable = true
+    },
+    {
+      name               = "date"
+      type               = "Date"
+      default_kind       = "MATERIALIZED"
+      default_expression = "toDate(time)"
+    }
+  ]
+
+  indexes = [
+    {
+      name        = "idx_value"
+      expression  = "value"
+      type        = "minmax"
+      granularity = 4
+    }
+  ]
+
+  constraints = [
+    {
+      name       = "c_id_positive"
+      expression = "id > 0"
+    }
+  ]
+
+  ttl = [
+    {
+      expression       = "time + INTERVAL 1 MONTH"
+      recompress_codec = "ZSTD(17)"
+    },
+    {
+      expression = "time + INTERVAL 1 YEAR"
     }
   ]
 }
@@ -36,3 +36,54 @@ resource "clickhouse_user" "user_sha256_password" {
     sha256_password = "qwerty12345"
   }
 }
+
+# CREATE USER user4 IDENTIFIED WITH double_sha1_hash
+# BY 'ed8e2c3fa4aa5d84ad5e4e17a7a0dbdd8f0b5e0f'
+# HOST ANY
+resource "clickhouse_user" "user_double_sha1_hash" {
+  name = "user4"
+
+  identified_with = {
+    double_sha1_hash = "ed8e2c3fa4aa5d84ad5e4e17a7a0dbdd8f0b5e0f"
+  }
+}
+
+# CREATE USER user5 IDENTIFIED WITH ldap SERVER 'my_ldap_server'
+# HOST ANY
+resource "clickhouse_user" "user_ldap" {
+  name = "user5"
+
+  identified_with = {
+    ldap = {
+      server = "my_ldap_server"
+    }
+  }
+}
+
+# CREATE USER user6 IDENTIFIED WITH ssl_certificate CN 'user6.example.com'
+# HOST ANY
+resource "clickhouse_user" "user_ssl_certificate" {
+  name = "user6"
+
+  identified_with = {
+    ssl_certificate = {
+      common_names = ["user6.example.com"]
+    }
+  }
+}
+
+# CREATE USER user7 IDENTIFIED WITH ssh_key
+# BY KEY 'AAAAC3NzaC1lZDI1NTE5AAAAIBfd2cNpWzsG5W0o1fK3bYlU6f1bnqFv6fDCZ6zVj0ZD' TYPE 'ssh-ed25519'
+# HOST ANY
+resource "clickhouse_user" "user_ssh_key" {
+  name = "user7"
+
+  identified_with = {
+    ssh_key = [
+      {
+        key  = "AAAAC3NzaC1lZDI1NTE5AAAAIBfd2cNpWzsG5W0o1fK3bYlU6f1bnqFv6fDCZ6zVj0ZD"
+        type = "ssh-ed25519"
+      },
+    ]
+  }
+}
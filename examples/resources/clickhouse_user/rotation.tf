@@ -0,0 +1,22 @@
+# Zero-downtime password rotation.
+# 1. Append the new password and apply:
+#    ALTER USER service ADD IDENTIFIED WITH sha256_hash BY '...'
+# 2. Roll out clients with the new password.
+# 3. Remove the old password and apply:
+#    ALTER USER service IDENTIFIED WITH sha256_hash BY '...'
+resource "clickhouse_user" "service" {
+  name = "service"
+
+  authentication_methods = [
+    {
+      sha256_hash = {
+        hash = "87428fc522803d31065e7bce3cf03fe475096631e5e07bbd7a0fde60c4cf25c7"
+      }
+    },
+    {
+      sha256_hash = {
+        hash = "2b915881367d1bd1ed3ab58b9fccc69fe4e3ee5492ab654ebd56c989ea6bd571"
+      }
+    },
+  ]
+}
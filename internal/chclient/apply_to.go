@@ -0,0 +1,51 @@
+package chclient
+
+import (
+	"strings"
+)
+
+// ClickHouseApplyTo describes users and roles an access entity (settings profile, quota, row policy)
+// is applied to (`TO` clause). It matches apply_to_* columns of the corresponding system tables.
+type ClickHouseApplyTo struct {
+	All    bool
+	Names  []string
+	Except []string
+}
+
+func quoteAndJoinIDs(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, QuoteID(name))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// String returns the content of `TO` clause. A nil ClickHouseApplyTo means nobody.
+func (to *ClickHouseApplyTo) String() string {
+	if to == nil {
+		return "NONE"
+	}
+
+	result := ""
+	if to.All {
+		result = "ALL"
+	} else if len(to.Names) > 0 {
+		result = quoteAndJoinIDs(to.Names)
+	} else {
+		return "NONE"
+	}
+
+	if len(to.Except) > 0 {
+		result += " EXCEPT " + quoteAndJoinIDs(to.Except)
+	}
+
+	return result
+}
+
+func newClickHouseApplyTo(all bool, names, except []string) *ClickHouseApplyTo {
+	if !all && len(names) == 0 {
+		return nil
+	}
+
+	return &ClickHouseApplyTo{All: all, Names: names, Except: except}
+}
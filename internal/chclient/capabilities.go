@@ -0,0 +1,49 @@
+package chclient
+
+import (
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+)
+
+// Capability is a feature which is available starting from a certain ClickHouse version.
+type Capability struct {
+	// Feature describes the feature for error messages, e.g. "ssh_key identification".
+	Feature    string
+	MinVersion proto.Version
+}
+
+// Capabilities of ClickHouse used by the provider which are not available in all supported versions.
+var (
+	CapabilityProjections         = Capability{Feature: "projections", MinVersion: proto.Version{Major: 21, Minor: 6}}
+	CapabilityModifyQuery         = Capability{Feature: "ALTER TABLE ... MODIFY QUERY", MinVersion: proto.Version{Major: 21, Minor: 12}}
+	CapabilityAssumeConstraints   = Capability{Feature: "ASSUME constraints", MinVersion: proto.Version{Major: 21, Minor: 12}}
+	CapabilityEphemeralColumns    = Capability{Feature: "EPHEMERAL columns", MinVersion: proto.Version{Major: 22, Minor: 3}}
+	CapabilitySSHKeyAuth          = Capability{Feature: "ssh_key identification", MinVersion: proto.Version{Major: 23, Minor: 9}}
+	CapabilityMultipleAuthMethods = Capability{Feature: "multiple authentication methods", MinVersion: proto.Version{Major: 24, Minor: 9}}
+)
+
+// Supports reports whether the server has the capability.
+// If the server version is unknown, every capability is assumed to be available and ClickHouse validates queries itself.
+func (client *ClickHouseClient) Supports(capability Capability) bool {
+	if client.ServerVersion == (proto.Version{}) {
+		return true
+	}
+	return proto.CheckMinVersion(capability.MinVersion, client.ServerVersion)
+}
+
+// RequireCapability returns NotSupportedError if the server does not have the capability.
+func (client *ClickHouseClient) RequireCapability(capability Capability) error {
+	if client.Supports(capability) {
+		return nil
+	}
+	return &NotSupportedError{
+		Operation: capability.Feature,
+		Detail: fmt.Sprintf(
+			"requires ClickHouse %d.%d or newer, server version is %s",
+			capability.MinVersion.Major,
+			capability.MinVersion.Minor,
+			client.ServerVersion,
+		),
+	}
+}
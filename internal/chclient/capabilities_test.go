@@ -0,0 +1,54 @@
+package chclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+)
+
+func TestRequireCapability(t *testing.T) {
+	testCases := []struct {
+		name      string
+		version   proto.Version
+		supported bool
+	}{
+		{name: "Older major version", version: proto.Version{Major: 23, Minor: 12, Patch: 1}, supported: false},
+		{name: "Older minor version", version: proto.Version{Major: 24, Minor: 3, Patch: 5}, supported: false},
+		{name: "Minimal version", version: proto.Version{Major: 24, Minor: 9}, supported: true},
+		{name: "Newer version", version: proto.Version{Major: 25, Minor: 1, Patch: 2}, supported: true},
+		{name: "Unknown version", version: proto.Version{}, supported: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := ClickHouseClient{ServerVersion: tc.version}
+			if actual := client.Supports(CapabilityMultipleAuthMethods); actual != tc.supported {
+				t.Errorf("expected %v, got %v", tc.supported, actual)
+			}
+
+			err := client.RequireCapability(CapabilityMultipleAuthMethods)
+			var notSupportedError *NotSupportedError
+			if tc.supported != (err == nil) || !tc.supported && !errors.As(err, &notSupportedError) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthColumnExpressions(t *testing.T) {
+	for _, tc := range []struct {
+		version  proto.Version
+		authType string
+	}{
+		{version: proto.Version{Major: 24, Minor: 8, Patch: 4}, authType: `[toString("auth_type")]`},
+		{version: proto.Version{Major: 24, Minor: 10, Patch: 1}, authType: `arrayMap(x -> toString(x), "auth_type")`},
+	} {
+		client := ClickHouseClient{ServerVersion: tc.version}
+		authType, _, err := client.userAuthColumns(context.Background())
+		if err != nil || authType != tc.authType {
+			t.Errorf("%s: expected %s, got %s, %v", tc.version, tc.authType, authType, err)
+		}
+	}
+}
@@ -5,24 +5,39 @@ import (
 	"fmt"
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
 )
 
 type dict map[string]interface{}
 type ClickHouseClient struct {
 	Conn driver.Conn
+	// Cluster is the name of a cluster to run DDL queries on. If empty, queries are run on a single node.
+	Cluster string
+	// ServerVersion is the version of the server the client is connected to. See Supports.
+	ServerVersion proto.Version
 }
 
-func NewClickHouseClient(connOpts *clickhouse.Options) (*ClickHouseClient, error) {
+// NewClickHouseClient connects to ClickHouse. If retryPolicy is nil, failed queries are not retried.
+func NewClickHouseClient(connOpts *clickhouse.Options, tlsOpts *TLSOptions, retryPolicy *RetryPolicy) (*ClickHouseClient, error) {
 	if connOpts == nil {
 		return nil, fmt.Errorf("*clickhouse.Options cannot be nil")
 	}
 
+	// Both native and HTTP connections take TLS settings from connOpts.TLS:
+	// HTTP connection switches to https scheme when it is set.
+	if tlsOpts != nil {
+		tlsConfig, err := tlsOpts.Config()
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS configuration: %w", err)
+		}
+		connOpts.TLS = tlsConfig
+	}
+
 	var conn driver.Conn
 	if connOpts.Protocol == clickhouse.HTTP {
 		db := clickhouse.OpenDB(connOpts)
 		if db == nil {
-			return nil, fmt.Errorf("cannot connect to ClickHouse " +
-				"[HTTP protocol is experimental, try native TCP protocol]")
+			return nil, fmt.Errorf("cannot connect to ClickHouse over HTTP")
 		}
 		conn = &HttpConn{DB: db}
 	} else {
@@ -33,12 +48,23 @@ func NewClickHouseClient(connOpts *clickhouse.Options) (*ClickHouseClient, error
 		}
 	}
 
+	// ClickHouse exceptions are converted into typed errors, e.g. UnknownEntityError or AccessDeniedError.
+	conn = &exceptionConn{Conn: conn}
+	if retryPolicy != nil {
+		conn = &retryConn{Conn: conn, policy: *retryPolicy}
+	}
+
 	err := conn.Ping(context.TODO())
 	if err != nil {
 		return nil, err
 	}
 
-	return &ClickHouseClient{conn}, nil
+	version, err := conn.ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("cannot detect ClickHouse version: %w", err)
+	}
+
+	return &ClickHouseClient{Conn: conn, ServerVersion: version.Version}, nil
 }
 
 type ClickHouseClientError interface {
@@ -0,0 +1,79 @@
+package chclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// WithCluster returns a copy of the client that runs DDL queries on the given cluster.
+// If cluster is empty, the client is returned as is.
+func (client *ClickHouseClient) WithCluster(cluster string) *ClickHouseClient {
+	if cluster == "" {
+		return client
+	}
+
+	c := *client
+	c.Cluster = cluster
+	return &c
+}
+
+// onCluster returns ` ON CLUSTER <name>` clause if the client is configured to work with a cluster.
+func (client *ClickHouseClient) onCluster() string {
+	if client.Cluster == "" {
+		return ""
+	}
+
+	return " ON CLUSTER " + QuoteID(client.Cluster)
+}
+
+// checkOnAllReplicas makes sure that an entity exists on every replica of the cluster.
+// Rows of systemTable matching condition are looked up with clusterAllReplicas.
+// If the entity is missing on some replicas, NotFoundError is returned.
+func (client *ClickHouseClient) checkOnAllReplicas(ctx context.Context, entity, name, systemTable, condition string) error {
+	if client.Cluster == "" {
+		return nil
+	}
+
+	query := fmt.Sprintf(
+		`SELECT
+    (SELECT count() FROM "system"."clusters" WHERE "cluster" = %s),
+    (SELECT uniqExact(hostName(), tcpPort()) FROM clusterAllReplicas(%s, "system".%s) WHERE %s)`,
+		QuoteValue(client.Cluster),
+		QuoteValue(client.Cluster),
+		QuoteID(systemTable),
+		condition,
+	)
+
+	tflog.Info(ctx, "Checking replicas of the cluster", dict{"query": query})
+
+	rows, err := client.Conn.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return fmt.Errorf("cannot check replicas of cluster %s: empty result of query: %s", client.Cluster, query)
+	}
+
+	var replicas, found uint64
+	if err := rows.Scan(&replicas, &found); err != nil {
+		return err
+	}
+
+	if replicas == 0 {
+		return fmt.Errorf("cluster %s is not defined on the server", client.Cluster)
+	}
+
+	if found < replicas {
+		return &NotFoundError{
+			Entity: entity,
+			Name:   fmt.Sprintf("%s (exists on %d of %d replicas of cluster %s)", name, found, replicas, client.Cluster),
+			Query:  query,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package chclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestOnClusterSQL(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	conn := mock_driver.NewMockConn(mockCtrl)
+	conn.EXPECT().Exec(ctx, `CREATE DATABASE "my_db" ON CLUSTER "my_cluster" ENGINE = "Atomic"`).Return(nil).Times(1)
+	conn.EXPECT().Exec(ctx, `CREATE DATABASE "my_db" ENGINE = "Atomic"`).Return(nil).Times(1)
+	conn.EXPECT().Exec(ctx, `GRANT ON CLUSTER "my_cluster" "my_role" TO "my_user"`).Return(nil).Times(1)
+
+	client := &ClickHouseClient{Conn: conn}
+	db := ClickHouseDatabase{Name: "my_db", Engine: ATOMIC}
+
+	if err := client.WithCluster("my_cluster").CreateDatabase(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CreateDatabase(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.WithCluster("my_cluster").GrantRole(ctx, "my_role", "my_user", false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckOnAllReplicas(t *testing.T) {
+	testCases := []struct {
+		name     string
+		replicas uint64
+		found    uint64
+		notFound bool
+	}{
+		{name: "Exists on all replicas", replicas: 3, found: 3},
+		{name: "Missing on a replica", replicas: 3, found: 2, notFound: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			conn := mock_driver.NewMockConn(mockCtrl)
+			rows := mock_driver.NewMockRows(mockCtrl)
+			rows.EXPECT().Next().Return(true).Times(1)
+			rows.EXPECT().Close().Return(nil).Times(1)
+			rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+				replicas, ok1 := dest[0].(*uint64)
+				found, ok2 := dest[1].(*uint64)
+				if !ok1 || !ok2 {
+					return errors.New("unexpected scan destination")
+				}
+				*replicas, *found = tc.replicas, tc.found
+				return nil
+			}).Times(1)
+
+			expectedQuery := `SELECT
+    (SELECT count() FROM "system"."clusters" WHERE "cluster" = 'my_cluster'),
+    (SELECT uniqExact(hostName(), tcpPort()) FROM clusterAllReplicas('my_cluster', "system"."roles") WHERE "name" = 'my_role')`
+			conn.EXPECT().Query(ctx, expectedQuery).Return(rows, nil).Times(1)
+
+			client := (&ClickHouseClient{Conn: conn}).WithCluster("my_cluster")
+			err := client.checkOnAllReplicas(ctx, "role", "my_role", "roles", `"name" = 'my_role'`)
+
+			var notFoundError *NotFoundError
+			if tc.notFound != errors.As(err, &notFoundError) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
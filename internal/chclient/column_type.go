@@ -0,0 +1,483 @@
+package chclient
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ColumnType is a parsed ClickHouse data type, e.g. `Array(Nullable(String))` or `Decimal(18, 4)`.
+// See: https://clickhouse.com/docs/en/sql-reference/data-types
+type ColumnType struct {
+	Name string
+	Args []ColumnTypeArg
+}
+
+// ColumnTypeArg is a parameter of a data type. It is either a nested type or a literal,
+// e.g. precision of `DateTime64(3, 'UTC')` or a function of `AggregateFunction(uniq, UInt64)`.
+type ColumnTypeArg struct {
+	// Name is set for named elements of `Tuple` and `Nested`.
+	Name    string
+	Type    *ColumnType
+	Literal string
+}
+
+// columnTypes lists known data types. Type names are matched case-insensitively.
+var columnTypes = []string{
+	"UInt8", "UInt16", "UInt32", "UInt64", "UInt128", "UInt256",
+	"Int8", "Int16", "Int32", "Int64", "Int128", "Int256",
+	"Float32", "Float64", "BFloat16",
+	"Decimal", "Decimal32", "Decimal64", "Decimal128", "Decimal256",
+	"Bool", "String", "FixedString", "UUID",
+	"Date", "Date32", "DateTime", "DateTime32", "DateTime64", "Time", "Time64",
+	"Enum", "Enum8", "Enum16",
+	"IPv4", "IPv6",
+	"Nullable", "LowCardinality", "Array", "Map", "Tuple", "Nested",
+	"Variant", "Dynamic", "JSON", "Object",
+	"Point", "Ring", "LineString", "MultiLineString", "Polygon", "MultiPolygon",
+	"AggregateFunction", "SimpleAggregateFunction", "Nothing",
+	"IntervalNanosecond", "IntervalMicrosecond", "IntervalMillisecond", "IntervalSecond", "IntervalMinute",
+	"IntervalHour", "IntervalDay", "IntervalWeek", "IntervalMonth", "IntervalQuarter", "IntervalYear",
+}
+
+// columnTypeAliases maps lower case aliases, mostly from other DBMS, to ClickHouse data types.
+var columnTypeAliases = map[string]string{
+	"boolean":    "Bool",
+	"tinyint":    "Int8",
+	"int1":       "Int8",
+	"byte":       "Int8",
+	"smallint":   "Int16",
+	"int2":       "Int16",
+	"int":        "Int32",
+	"integer":    "Int32",
+	"int4":       "Int32",
+	"mediumint":  "Int32",
+	"bigint":     "Int64",
+	"signed":     "Int64",
+	"unsigned":   "UInt64",
+	"float":      "Float32",
+	"real":       "Float32",
+	"single":     "Float32",
+	"double":     "Float64",
+	"dec":        "Decimal",
+	"numeric":    "Decimal",
+	"fixed":      "Decimal",
+	"text":       "String",
+	"varchar":    "String",
+	"char":       "String",
+	"character":  "String",
+	"nchar":      "String",
+	"nvarchar":   "String",
+	"varchar2":   "String",
+	"clob":       "String",
+	"blob":       "String",
+	"tinytext":   "String",
+	"mediumtext": "String",
+	"longtext":   "String",
+	"tinyblob":   "String",
+	"mediumblob": "String",
+	"longblob":   "String",
+	"bytea":      "String",
+	"varbinary":  "String",
+	"binary":     "FixedString",
+	"timestamp":  "DateTime",
+	"inet4":      "IPv4",
+	"inet6":      "IPv6",
+}
+
+// decimalPrecisions are precisions of Decimal types with a fixed size.
+var decimalPrecisions = map[string]string{
+	"Decimal32":  "9",
+	"Decimal64":  "18",
+	"Decimal128": "38",
+	"Decimal256": "76",
+}
+
+// literalArgsTypes are types whose parameters are literals rather than nested types.
+var literalArgsTypes = map[string]bool{
+	"Decimal":     true,
+	"Decimal32":   true,
+	"Decimal64":   true,
+	"Decimal128":  true,
+	"Decimal256":  true,
+	"FixedString": true,
+	"DateTime":    true,
+	"DateTime32":  true,
+	"DateTime64":  true,
+	"Time64":      true,
+	"Enum":        true,
+	"Enum8":       true,
+	"Enum16":      true,
+	"Dynamic":     true,
+	"JSON":        true,
+	"Object":      true,
+	// Parameters of String aliases, e.g. VARCHAR(255), are ignored.
+	"String": true,
+}
+
+// ParseColumnType parses and validates a ClickHouse data type. Aliases are replaced with canonical
+// type names, e.g. `INT` becomes `Int32`, so the result is comparable with types reported by ClickHouse.
+func ParseColumnType(s string) (*ColumnType, error) {
+	tokens, err := tokenizeColumnType(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &columnTypeParser{tokens: tokens}
+	t, err := p.parseType()
+	if err != nil {
+		return nil, fmt.Errorf("invalid type %q: %w", s, err)
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("invalid type %q: unexpected %q", s, p.peek().text)
+	}
+
+	return t, nil
+}
+
+// NormalizeColumnType returns the canonical form of a data type as ClickHouse shows it in system.columns.
+func NormalizeColumnType(s string) (string, error) {
+	t, err := ParseColumnType(s)
+	if err != nil {
+		return "", err
+	}
+	return t.String(), nil
+}
+
+// SameColumnType reports whether both strings denote the same data type.
+func SameColumnType(a, b string) bool {
+	normalizedA, errA := NormalizeColumnType(a)
+	normalizedB, errB := NormalizeColumnType(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return normalizedA == normalizedB
+}
+
+// IsNullable reports whether the type is `Nullable(...)`.
+func (t *ColumnType) IsNullable() bool {
+	return t.Name == "Nullable" && len(t.Args) == 1 && t.Args[0].Type != nil
+}
+
+// Unwrap returns the type inside `Nullable(...)`, or the type itself for other types.
+func (t *ColumnType) Unwrap() *ColumnType {
+	if t.IsNullable() {
+		return t.Args[0].Type
+	}
+	return t
+}
+
+func (t *ColumnType) String() string {
+	if len(t.Args) == 0 {
+		return t.Name
+	}
+
+	args := make([]string, 0, len(t.Args))
+	for _, arg := range t.Args {
+		var value string
+		if arg.Type != nil {
+			value = arg.Type.String()
+		} else {
+			value = arg.Literal
+		}
+		if arg.Name != "" {
+			value = arg.Name + " " + value
+		}
+		args = append(args, value)
+	}
+
+	return t.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// normalize brings parameters of the type to the form used by ClickHouse.
+func (t *ColumnType) normalize() {
+	switch t.Name {
+	case "Decimal":
+		switch len(t.Args) {
+		case 0:
+			t.Args = []ColumnTypeArg{{Literal: "10"}, {Literal: "0"}}
+		case 1:
+			t.Args = append(t.Args, ColumnTypeArg{Literal: "0"})
+		}
+	case "Decimal32", "Decimal64", "Decimal128", "Decimal256":
+		if len(t.Args) == 1 {
+			t.Args = []ColumnTypeArg{{Literal: decimalPrecisions[t.Name]}, t.Args[0]}
+			t.Name = "Decimal"
+		}
+	}
+}
+
+type columnTypeTokenKind int
+
+const (
+	tokenIdent columnTypeTokenKind = iota
+	tokenNumber
+	tokenString
+	tokenPunct
+)
+
+type columnTypeToken struct {
+	kind columnTypeTokenKind
+	text string
+}
+
+func tokenizeColumnType(s string) ([]columnTypeToken, error) {
+	var tokens []columnTypeToken
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("(),=-+.", r):
+			tokens = append(tokens, columnTypeToken{kind: tokenPunct, text: string(r)})
+			i++
+		case r == '\'' || r == '`' || r == '"':
+			start := i
+			i++
+			for i < len(runes) {
+				if runes[i] == '\\' {
+					i += 2
+					continue
+				}
+				// Quotes inside quoted strings may be escaped by doubling them.
+				if runes[i] == r && (i+1 >= len(runes) || runes[i+1] != r) {
+					break
+				}
+				if runes[i] == r {
+					i++
+				}
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("invalid type %q: unterminated quote", s)
+			}
+			i++
+			kind := tokenString
+			if r != '\'' {
+				kind = tokenIdent
+			}
+			tokens = append(tokens, columnTypeToken{kind: kind, text: string(runes[start:i])})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || unicode.IsLetter(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, columnTypeToken{kind: tokenNumber, text: string(runes[start:i])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, columnTypeToken{kind: tokenIdent, text: string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("invalid type %q: unexpected character %q", s, r)
+		}
+	}
+
+	return tokens, nil
+}
+
+type columnTypeParser struct {
+	tokens []columnTypeToken
+	pos    int
+}
+
+func (p *columnTypeParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *columnTypeParser) peek() columnTypeToken {
+	if p.done() {
+		return columnTypeToken{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *columnTypeParser) peekAt(offset int) columnTypeToken {
+	if p.pos+offset >= len(p.tokens) {
+		return columnTypeToken{}
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *columnTypeParser) next() columnTypeToken {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *columnTypeParser) isPunct(text string) bool {
+	token := p.peek()
+	return !p.done() && token.kind == tokenPunct && token.text == text
+}
+
+func (p *columnTypeParser) expectPunct(text string) error {
+	if !p.isPunct(text) {
+		if p.done() {
+			return fmt.Errorf("expected %q, got end of input", text)
+		}
+		return fmt.Errorf("expected %q, got %q", text, p.peek().text)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *columnTypeParser) parseType() (*ColumnType, error) {
+	token := p.next()
+	if token.kind != tokenIdent {
+		if token.text == "" {
+			return nil, fmt.Errorf("expected type name, got end of input")
+		}
+		return nil, fmt.Errorf("expected type name, got %q", token.text)
+	}
+
+	name, err := p.resolveTypeName(token.text)
+	if err != nil {
+		return nil, err
+	}
+	t := &ColumnType{Name: name}
+
+	if p.isPunct("(") {
+		p.pos++
+		args, err := p.parseArgs(t.Name)
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		if t.Name != "String" {
+			t.Args = args
+		}
+	}
+
+	t.normalize()
+	return t, nil
+}
+
+// resolveTypeName returns the canonical name of the type. Modifiers like in `INT UNSIGNED`
+// or `DOUBLE PRECISION` are consumed as well.
+func (p *columnTypeParser) resolveTypeName(name string) (string, error) {
+	canonical := ""
+	for _, t := range columnTypes {
+		if strings.EqualFold(t, name) {
+			canonical = t
+			break
+		}
+	}
+	if canonical == "" {
+		canonical = columnTypeAliases[strings.ToLower(name)]
+	}
+	if canonical == "" {
+		return "", fmt.Errorf("unknown type %q", name)
+	}
+
+	modifier := p.peek()
+	if modifier.kind != tokenIdent || !isTypeModifier(modifier.text) {
+		return canonical, nil
+	}
+	switch strings.ToUpper(modifier.text) {
+	case "SIGNED":
+		if strings.HasPrefix(canonical, "Int") && !strings.HasPrefix(canonical, "Interval") {
+			p.pos++
+		}
+	case "UNSIGNED":
+		if strings.HasPrefix(canonical, "Int") && !strings.HasPrefix(canonical, "Interval") {
+			p.pos++
+			canonical = "U" + canonical
+		}
+	case "PRECISION":
+		if canonical == "Float64" {
+			p.pos++
+		}
+	}
+
+	return canonical, nil
+}
+
+func isTypeModifier(s string) bool {
+	switch strings.ToUpper(s) {
+	case "SIGNED", "UNSIGNED", "PRECISION":
+		return true
+	}
+	return false
+}
+
+func (p *columnTypeParser) parseArgs(typeName string) ([]ColumnTypeArg, error) {
+	var args []ColumnTypeArg
+	if p.isPunct(")") {
+		return args, nil
+	}
+
+	for i := 0; ; i++ {
+		var arg ColumnTypeArg
+		var err error
+
+		switch {
+		case literalArgsTypes[typeName]:
+			arg.Literal, err = p.parseLiteral()
+		case (typeName == "AggregateFunction" || typeName == "SimpleAggregateFunction") && i == 0:
+			arg.Literal, err = p.parseLiteral()
+		case typeName == "Tuple" || typeName == "Nested":
+			if p.peek().kind == tokenIdent && p.peekAt(1).kind == tokenIdent && !isTypeModifier(p.peekAt(1).text) {
+				arg.Name = p.next().text
+			}
+			arg.Type, err = p.parseType()
+		default:
+			arg.Type, err = p.parseType()
+		}
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		if !p.isPunct(",") {
+			return args, nil
+		}
+		p.pos++
+	}
+}
+
+// parseLiteral reads tokens up to the next top-level comma or closing bracket
+// and joins them the way ClickHouse formats them.
+func (p *columnTypeParser) parseLiteral() (string, error) {
+	var b strings.Builder
+	depth := 0
+	var prev columnTypeToken
+
+	for !p.done() {
+		token := p.peek()
+		if token.kind == tokenPunct {
+			if depth == 0 && (token.text == "," || token.text == ")") {
+				break
+			}
+			switch token.text {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			}
+		}
+		p.pos++
+
+		switch {
+		case b.Len() == 0:
+		case token.text == "=" || prev.text == "=" || prev.text == ",":
+			b.WriteString(" ")
+		case token.kind != tokenPunct && prev.kind != tokenPunct:
+			b.WriteString(" ")
+		}
+		b.WriteString(token.text)
+		prev = token
+	}
+
+	if b.Len() == 0 {
+		return "", fmt.Errorf("expected type parameter")
+	}
+	if depth != 0 {
+		return "", fmt.Errorf("unbalanced brackets")
+	}
+	return b.String(), nil
+}
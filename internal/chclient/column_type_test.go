@@ -0,0 +1,117 @@
+package chclient
+
+import (
+	"testing"
+)
+
+func TestNormalizeColumnType(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"String", "String"},
+		{"string", "String"},
+		{"INT", "Int32"},
+		{"INT UNSIGNED", "UInt32"},
+		{"BIGINT", "Int64"},
+		{"BOOL", "Bool"},
+		{"Boolean", "Bool"},
+		{"DOUBLE PRECISION", "Float64"},
+		{"VARCHAR(255)", "String"},
+		{"Decimal", "Decimal(10, 0)"},
+		{"Decimal(18,4)", "Decimal(18, 4)"},
+		{"Decimal32(4)", "Decimal(9, 4)"},
+		{"DateTime64(3,'UTC')", "DateTime64(3, 'UTC')"},
+		{"DateTime('Europe/Moscow')", "DateTime('Europe/Moscow')"},
+		{"FixedString(16)", "FixedString(16)"},
+		{"Array(String)", "Array(String)"},
+		{"Array(Array(Nullable(INT)))", "Array(Array(Nullable(Int32)))"},
+		{"Map(String,UInt64)", "Map(String, UInt64)"},
+		{"Tuple(String, UInt8)", "Tuple(String, UInt8)"},
+		{"Tuple(a String, b Nullable(Float64))", "Tuple(a String, b Nullable(Float64))"},
+		{"Nested(id UInt32, name String)", "Nested(id UInt32, name String)"},
+		{"Enum8('a'=1,'b'=-2)", "Enum8('a' = 1, 'b' = -2)"},
+		{"Enum16('it''s' = 1)", "Enum16('it''s' = 1)"},
+		{"LowCardinality(String)", "LowCardinality(String)"},
+		{"LowCardinality(Nullable(String))", "LowCardinality(Nullable(String))"},
+		{"AggregateFunction(uniq, UInt64)", "AggregateFunction(uniq, UInt64)"},
+		{"AggregateFunction(quantiles(0.5,0.9), UInt64)", "AggregateFunction(quantiles(0.5, 0.9), UInt64)"},
+		{"SimpleAggregateFunction(sum, Double)", "SimpleAggregateFunction(sum, Float64)"},
+		{"Variant(String, Array(UInt64))", "Variant(String, Array(UInt64))"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := NormalizeColumnType(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseColumnTypeErrors(t *testing.T) {
+	testCases := []string{
+		"",
+		"Strin",
+		"Array(",
+		"Array(String",
+		"Array(String))",
+		"Map(String UInt64)",
+		"Decimal(18, 4",
+		"Enum8('a = 1)",
+		"String; DROP TABLE x",
+	}
+
+	for _, input := range testCases {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseColumnType(input); err == nil {
+				t.Errorf("expected error for %q", input)
+			}
+		})
+	}
+}
+
+func TestColumnTypeNullable(t *testing.T) {
+	testCases := []struct {
+		input            string
+		expectedNullable bool
+		expectedInner    string
+	}{
+		{"Nullable(String)", true, "String"},
+		{"Nullable(Decimal(18, 4))", true, "Decimal(18, 4)"},
+		{"Nullable(DateTime64(3, 'UTC'))", true, "DateTime64(3, 'UTC')"},
+		{"Array(Nullable(String))", false, "Array(Nullable(String))"},
+		{"LowCardinality(Nullable(String))", false, "LowCardinality(Nullable(String))"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			columnType, err := ParseColumnType(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if columnType.IsNullable() != tc.expectedNullable {
+				t.Errorf("expected nullable %v, got %v", tc.expectedNullable, columnType.IsNullable())
+			}
+			if inner := columnType.Unwrap().String(); inner != tc.expectedInner {
+				t.Errorf("expected %q, got %q", tc.expectedInner, inner)
+			}
+		})
+	}
+}
+
+func TestSameColumnType(t *testing.T) {
+	if !SameColumnType("INT", "Int32") {
+		t.Error("INT and Int32 should be the same type")
+	}
+	if !SameColumnType("Decimal(18,4)", "Decimal(18, 4)") {
+		t.Error("Decimal(18,4) and Decimal(18, 4) should be the same type")
+	}
+	if SameColumnType("Int32", "Int64") {
+		t.Error("Int32 and Int64 should be different types")
+	}
+}
@@ -46,8 +46,9 @@ type ClickHouseDatabase struct {
 
 func (client *ClickHouseClient) CreateDatabase(ctx context.Context, database ClickHouseDatabase) error {
 	query := fmt.Sprintf(
-		"CREATE DATABASE %s ENGINE = %s",
+		"CREATE DATABASE %s%s ENGINE = %s",
 		QuoteID(database.Name),
+		client.onCluster(),
 		QuoteID(database.Engine.String()),
 	)
 
@@ -62,8 +63,9 @@ func (client *ClickHouseClient) CreateDatabase(ctx context.Context, database Cli
 
 func (client *ClickHouseClient) DropDatabase(ctx context.Context, database string) error {
 	query := fmt.Sprintf(
-		"DROP DATABASE %s SYNC",
+		"DROP DATABASE %s%s SYNC",
 		QuoteID(database),
+		client.onCluster(),
 	)
 
 	tflog.Info(ctx, "Dropping a database", dict{"query": query})
@@ -99,6 +101,11 @@ func (client *ClickHouseClient) GetDatabase(ctx context.Context, name string) (C
 		return ClickHouseDatabase{}, err
 	}
 
+	err = client.checkOnAllReplicas(ctx, "database", name, "databases", `"name" = `+QuoteValue(name))
+	if err != nil {
+		return ClickHouseDatabase{}, err
+	}
+
 	return ClickHouseDatabase{
 		Name:    nameReceived,
 		Engine:  DatabaseEngineFromString(engine),
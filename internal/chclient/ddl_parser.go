@@ -0,0 +1,333 @@
+package chclient
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type ddlTokenKind int
+
+const (
+	ddlIdent ddlTokenKind = iota
+	ddlQuotedIdent
+	ddlString
+	ddlNumber
+	ddlPunct
+)
+
+// ddlToken is a lexical token of a ClickHouse DDL fragment.
+// Text of quoted identifiers and string literals is unquoted and unescaped,
+// start and end point to the raw token in the source.
+type ddlToken struct {
+	kind  ddlTokenKind
+	text  string
+	start int
+	end   int
+}
+
+func (token ddlToken) isPunct(text string) bool {
+	return token.kind == ddlPunct && token.text == text
+}
+
+func tokenizeDDL(s string) ([]ddlToken, error) {
+	var tokens []ddlToken
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '\'' || r == '"' || r == '`':
+			text, end, err := unquoteDDL(s, i)
+			if err != nil {
+				return nil, err
+			}
+			kind := ddlString
+			if r != '\'' {
+				kind = ddlQuotedIdent
+			}
+			tokens = append(tokens, ddlToken{kind: kind, text: text, start: i, end: end})
+			i = end
+		case unicode.IsDigit(r):
+			end := scanDDLWord(s, i)
+			tokens = append(tokens, ddlToken{kind: ddlNumber, text: s[i:end], start: i, end: end})
+			i = end
+		case unicode.IsLetter(r) || r == '_':
+			end := scanDDLWord(s, i)
+			tokens = append(tokens, ddlToken{kind: ddlIdent, text: s[i:end], start: i, end: end})
+			i = end
+		default:
+			tokens = append(tokens, ddlToken{kind: ddlPunct, text: s[i : i+size], start: i, end: i + size})
+			i += size
+		}
+	}
+
+	return tokens, nil
+}
+
+// scanDDLWord returns the end of an identifier or a number starting at i.
+func scanDDLWord(s string, i int) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '$' && r != '.' {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// unquoteDDL reads a quoted string or identifier starting at i and returns its unescaped text
+// and the position right after the closing quote.
+func unquoteDDL(s string, i int) (string, int, error) {
+	quote := s[i]
+	var text strings.Builder
+
+	for j := i + 1; j < len(s); j++ {
+		c := s[j]
+		switch {
+		case c == '\\':
+			if j+1 >= len(s) {
+				return "", 0, fmt.Errorf("unterminated quote at position %d", i)
+			}
+			j++
+			switch s[j] {
+			case 'n':
+				text.WriteByte('\n')
+			case 't':
+				text.WriteByte('\t')
+			case 'r':
+				text.WriteByte('\r')
+			case 'b':
+				text.WriteByte('\b')
+			case 'f':
+				text.WriteByte('\f')
+			case '0':
+				text.WriteByte(0)
+			case 'x':
+				if j+2 < len(s) {
+					if b, err := strconv.ParseUint(s[j+1:j+3], 16, 8); err == nil {
+						text.WriteByte(byte(b))
+						j += 2
+						continue
+					}
+				}
+				text.WriteByte('x')
+			default:
+				text.WriteByte(s[j])
+			}
+		case c == quote:
+			// Quotes may also be escaped by doubling them.
+			if j+1 < len(s) && s[j+1] == quote {
+				text.WriteByte(quote)
+				j++
+				continue
+			}
+			return text.String(), j + 1, nil
+		default:
+			text.WriteByte(c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated quote at position %d", i)
+}
+
+// ddlDepths returns the bracket nesting level of every token. Brackets themselves get the outer level.
+func ddlDepths(tokens []ddlToken) ([]int, error) {
+	closing := map[string]string{"(": ")", "[": "]", "{": "}"}
+	depths := make([]int, len(tokens))
+	var stack []ddlToken
+
+	for i, token := range tokens {
+		if token.kind == ddlPunct {
+			switch token.text {
+			case "(", "[", "{":
+				depths[i] = len(stack)
+				stack = append(stack, token)
+				continue
+			case ")", "]", "}":
+				if len(stack) == 0 || closing[stack[len(stack)-1].text] != token.text {
+					return nil, fmt.Errorf("unexpected %q at position %d", token.text, token.start)
+				}
+				stack = stack[:len(stack)-1]
+			}
+		}
+		depths[i] = len(stack)
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("unclosed %q at position %d", stack[len(stack)-1].text, stack[len(stack)-1].start)
+	}
+	return depths, nil
+}
+
+// splitDDLTokens splits tokens by commas on the given nesting level.
+func splitDDLTokens(tokens []ddlToken, depths []int, depth int) [][]ddlToken {
+	var parts [][]ddlToken
+	start := 0
+
+	for i, token := range tokens {
+		if depths[i] == depth && token.isPunct(",") {
+			parts = append(parts, tokens[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, tokens[start:])
+}
+
+// rawDDL returns the source text of non-empty tokens.
+func rawDDL(s string, tokens []ddlToken) string {
+	return s[tokens[0].start:tokens[len(tokens)-1].end]
+}
+
+// ParseEngineParams returns parameters of the table engine from engine_full, e.g. the ZooKeeper path and
+// the replica name of `ReplicatedMergeTree('/clickhouse/tables/{shard}/t', '{replica}') ORDER BY id`.
+// String literals and identifiers are unquoted, so the result is accepted by FormatEngineParams.
+// Quoted identifiers which are not plain names and other parameters are returned as they are written.
+func ParseEngineParams(engineFull string) ([]string, error) {
+	params := make([]string, 0)
+
+	tokens, err := tokenizeDDL(engineFull)
+	if err != nil {
+		return nil, fmt.Errorf("invalid engine %q: %w", engineFull, err)
+	}
+	if len(tokens) == 0 {
+		return params, nil
+	}
+	if tokens[0].kind != ddlIdent && tokens[0].kind != ddlQuotedIdent {
+		return nil, fmt.Errorf("invalid engine %q: engine name expected", engineFull)
+	}
+	if len(tokens) < 2 || !tokens[1].isPunct("(") {
+		return params, nil
+	}
+
+	depths, err := ddlDepths(tokens)
+	if err != nil {
+		return nil, fmt.Errorf("invalid engine %q: %w", engineFull, err)
+	}
+
+	end := 2
+	for depths[end] > 0 {
+		end++
+	}
+	if end == 2 {
+		return params, nil
+	}
+
+	for _, param := range splitDDLTokens(tokens[2:end], depths[2:end], 1) {
+		switch {
+		case len(param) == 0:
+			return nil, fmt.Errorf("invalid engine %q: empty parameter", engineFull)
+		case len(param) == 1 && param[0].kind == ddlString:
+			params = append(params, param[0].text)
+		case len(param) == 1 && param[0].kind == ddlQuotedIdent && plainIdentifierRegexp.MatchString(param[0].text):
+			params = append(params, param[0].text)
+		default:
+			params = append(params, rawDDL(engineFull, param))
+		}
+	}
+
+	return params, nil
+}
+
+// FormatEngineParams joins engine parameters for CREATE TABLE. Plain names, e.g. a version column
+// of ReplacingMergeTree, are quoted as identifiers, while numbers, function calls, tuples and quoted identifiers
+// are used as they are. Other parameters, e.g. a ZooKeeper path, are quoted as string literals.
+func FormatEngineParams(params []string) string {
+	result := make([]string, 0, len(params))
+	for _, param := range params {
+		result = append(result, formatEngineParam(param))
+	}
+	return strings.Join(result, ", ")
+}
+
+func formatEngineParam(param string) string {
+	tokens, err := tokenizeDDL(param)
+	switch {
+	case err != nil || len(tokens) == 0:
+		return QuoteValue(param)
+	case len(tokens) == 1 && tokens[0].kind == ddlIdent && plainIdentifierRegexp.MatchString(param):
+		return QuoteWithTicks(param)
+	case len(tokens) == 1 && (tokens[0].kind == ddlNumber || tokens[0].kind == ddlQuotedIdent):
+		return param
+	case isDDLCall(tokens):
+		return param
+	default:
+		return QuoteValue(param)
+	}
+}
+
+// isDDLCall reports whether tokens form a function call like `cityHash64(user_id)` or a tuple like `(a, b)`.
+func isDDLCall(tokens []ddlToken) bool {
+	open := 0
+	if tokens[0].kind == ddlIdent {
+		open = 1
+	}
+	if len(tokens) < open+2 || !tokens[open].isPunct("(") || !tokens[len(tokens)-1].isPunct(")") {
+		return false
+	}
+
+	depths, err := ddlDepths(tokens)
+	if err != nil {
+		return false
+	}
+	for i := open + 1; i < len(tokens)-1; i++ {
+		if depths[i] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseSettings returns the SETTINGS clause of engine_full as a map.
+// String values are unquoted, other values are returned as they are written.
+func ParseSettings(engineFull string) (map[string]string, error) {
+	settings := make(map[string]string)
+
+	tokens, err := tokenizeDDL(engineFull)
+	if err != nil {
+		return nil, fmt.Errorf("invalid engine %q: %w", engineFull, err)
+	}
+	depths, err := ddlDepths(tokens)
+	if err != nil {
+		return nil, fmt.Errorf("invalid engine %q: %w", engineFull, err)
+	}
+
+	start := -1
+	for i, token := range tokens {
+		if depths[i] == 0 && token.kind == ddlIdent && strings.EqualFold(token.text, "SETTINGS") {
+			start = i + 1
+			break
+		}
+	}
+	if start == -1 {
+		return settings, nil
+	}
+	if start == len(tokens) {
+		return nil, fmt.Errorf("invalid engine %q: empty SETTINGS clause", engineFull)
+	}
+
+	for _, setting := range splitDDLTokens(tokens[start:], depths[start:], 0) {
+		if len(setting) < 3 ||
+			(setting[0].kind != ddlIdent && setting[0].kind != ddlQuotedIdent) ||
+			!setting[1].isPunct("=") {
+			if len(setting) == 0 {
+				return nil, fmt.Errorf("invalid engine %q: empty setting", engineFull)
+			}
+			return nil, fmt.Errorf("invalid engine %q: setting %q is not in form `name = value`", engineFull, rawDDL(engineFull, setting))
+		}
+
+		value := setting[2:]
+		if len(value) == 1 && value[0].kind == ddlString {
+			settings[setting[0].text] = value[0].text
+		} else {
+			settings[setting[0].text] = rawDDL(engineFull, value)
+		}
+	}
+
+	return settings, nil
+}
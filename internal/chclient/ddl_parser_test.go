@@ -0,0 +1,230 @@
+package chclient
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestParseEngineParams(t *testing.T) {
+	testCases := []struct {
+		engineFull string
+		expected   []string
+	}{
+		{engineFull: "", expected: []string{}},
+		{engineFull: "MergeTree ORDER BY id SETTINGS index_granularity = 8192", expected: []string{}},
+		{engineFull: "MergeTree PARTITION BY toYYYYMM(time) ORDER BY id", expected: []string{}},
+		{engineFull: "MergeTree() ORDER BY id", expected: []string{}},
+		{engineFull: "ReplacingMergeTree(time) PARTITION BY toYYYYMM(time) ORDER BY id", expected: []string{"time"}},
+		{engineFull: "ReplacingMergeTree(`version`, is_deleted) ORDER BY id", expected: []string{"version", "is_deleted"}},
+		{engineFull: "ReplacingMergeTree(`version column`) ORDER BY id", expected: []string{"`version column`"}},
+		{
+			engineFull: "ReplicatedMergeTree('/clickhouse/tables/{shard}/db/t', '{replica}') ORDER BY id",
+			expected:   []string{"/clickhouse/tables/{shard}/db/t", "{replica}"},
+		},
+		{
+			engineFull: "Distributed('cluster', 'db', 'events', cityHash64(user_id, concat('a,', 'b)'))) SETTINGS fsync_after_insert = 1",
+			expected:   []string{"cluster", "db", "events", "cityHash64(user_id, concat('a,', 'b)'))"},
+		},
+		{engineFull: "SummingMergeTree((a, b)) ORDER BY id", expected: []string{"(a, b)"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.engineFull, func(t *testing.T) {
+			actual, err := ParseEngineParams(tc.engineFull)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFormatEngineParams(t *testing.T) {
+	params := []string{"time", "8192", "`version column`", "cityHash64(user_id)", "(a, b)", "/clickhouse/tables/{shard}/t", "it's", "(a) + 1"}
+	expected := "`time`, 8192, `version column`, cityHash64(user_id), (a, b), '/clickhouse/tables/{shard}/t', 'it\\'s', '(a) + 1'"
+
+	if actual := FormatEngineParams(params); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestCreateTableEngineParamsRoundTrip(t *testing.T) {
+	for _, engineFull := range []string{
+		"ReplacingMergeTree(time) PARTITION BY toYYYYMM(time) ORDER BY id",
+		"ReplicatedMergeTree('/clickhouse/tables/{shard}/db/t', '{replica}', ver) ORDER BY id",
+		"ReplacingMergeTree(`version column`) ORDER BY id",
+		"Distributed('cluster', 'db', 'events', cityHash64(user_id, concat('a,', 'b)')))",
+		"Kafka('localhost:9092', 'topic', 'group', 'JSONEachRow') SETTINGS kafka_num_consumers = 2",
+	} {
+		t.Run(engineFull, func(t *testing.T) {
+			params, err := ParseEngineParams(engineFull)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			var createQuery string
+			conn := mock_driver.NewMockConn(mockCtrl)
+			conn.EXPECT().Exec(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, query string, _ ...any) error {
+				createQuery = query
+				return nil
+			}).Times(1)
+
+			client := &ClickHouseClient{Conn: conn}
+			table := ClickHouseTable{Database: "db", Name: "t", Engine: "Engine", EngineParams: params}
+			if err := client.CreateTable(ctx, table); err != nil {
+				t.Fatal(err)
+			}
+
+			_, engine, _ := strings.Cut(createQuery, "ENGINE = ")
+			actual, err := ParseEngineParams(engine)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, params) {
+				t.Errorf("expected %q, got %q from %s", params, actual, createQuery)
+			}
+		})
+	}
+}
+
+func TestParseEngineParamsErrors(t *testing.T) {
+	for _, engineFull := range []string{
+		"ReplacingMergeTree(time ORDER BY id",
+		"ReplacingMergeTree(time]) ORDER BY id",
+		"ReplicatedMergeTree('/clickhouse/tables, {replica}) ORDER BY id",
+		"ReplacingMergeTree(time, , is_deleted) ORDER BY id",
+		"(time) ORDER BY id",
+	} {
+		t.Run(engineFull, func(t *testing.T) {
+			_, err := ParseEngineParams(engineFull)
+			if err == nil {
+				t.Error("error expected")
+			}
+		})
+	}
+}
+
+func TestParseSettings(t *testing.T) {
+	testCases := []struct {
+		engineFull string
+		expected   map[string]string
+	}{
+		{engineFull: "MergeTree ORDER BY id", expected: map[string]string{}},
+		{
+			engineFull: "MergeTree ORDER BY id SETTINGS index_granularity = 8192, storage_policy = 'default'",
+			expected:   map[string]string{"index_granularity": "8192", "storage_policy": "default"},
+		},
+		{
+			engineFull: "MergeTree ORDER BY id SETTINGS index_granularity=8192,min_bytes_for_wide_part=0",
+			expected:   map[string]string{"index_granularity": "8192", "min_bytes_for_wide_part": "0"},
+		},
+		{
+			engineFull: "Kafka SETTINGS kafka_broker_list = 'host1:9092, host2:9092', kafka_topic_list = 'events', " +
+				"kafka_group_name = 'it\\'s a group', kafka_format = 'JSONEachRow'",
+			expected: map[string]string{
+				"kafka_broker_list": "host1:9092, host2:9092",
+				"kafka_topic_list":  "events",
+				"kafka_group_name":  "it's a group",
+				"kafka_format":      "JSONEachRow",
+			},
+		},
+		{
+			engineFull: "RabbitMQ SETTINGS rabbitmq_host_port = 'localhost:5672', rabbitmq_exchange_name = 'a''b', " +
+				"rabbitmq_format = 'TSV', rabbitmq_row_delimiter = '\\n'",
+			expected: map[string]string{
+				"rabbitmq_host_port":     "localhost:5672",
+				"rabbitmq_exchange_name": "a'b",
+				"rabbitmq_format":        "TSV",
+				"rabbitmq_row_delimiter": "\n",
+			},
+		},
+		{
+			engineFull: "MergeTree ORDER BY id TTL time + toIntervalDay(1) SETTINGS `merge_with_ttl_timeout` = 3600, ratio = -0.5",
+			expected:   map[string]string{"merge_with_ttl_timeout": "3600", "ratio": "-0.5"},
+		},
+		{
+			engineFull: "ReplicatedMergeTree('/path/SETTINGS', '{replica}') PARTITION BY tuple('SETTINGS x = 1') ORDER BY id",
+			expected:   map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.engineFull, func(t *testing.T) {
+			actual, err := ParseSettings(tc.engineFull)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseSettingsErrors(t *testing.T) {
+	for _, engineFull := range []string{
+		"MergeTree ORDER BY id SETTINGS",
+		"MergeTree ORDER BY id SETTINGS index_granularity",
+		"MergeTree ORDER BY id SETTINGS index_granularity = ",
+		"MergeTree ORDER BY id SETTINGS index_granularity = 8192,",
+		"MergeTree ORDER BY id SETTINGS storage_policy = 'default",
+		"MergeTree ORDER BY (id SETTINGS index_granularity = 8192",
+		"MergeTree ORDER BY id SETTINGS 1 = 2",
+	} {
+		t.Run(engineFull, func(t *testing.T) {
+			_, err := ParseSettings(engineFull)
+			if err == nil {
+				t.Error("error expected")
+			}
+		})
+	}
+}
+
+func FuzzParseSettings(f *testing.F) {
+	f.Add("index_granularity", "8192")
+	f.Add("kafka_broker_list", "host1:9092, host2:9092")
+	f.Add("kafka_group_name", "it's a 'group'")
+	f.Add("format_csv_delimiter", `\`)
+	f.Add("weird `name`", "SETTINGS a = 'b', (c")
+
+	f.Fuzz(func(t *testing.T, name, value string) {
+		if name == "" || strings.ContainsRune(name+value, 0) || !utf8.ValidString(name+value) {
+			t.Skip()
+		}
+
+		engineFull := "MergeTree ORDER BY id SETTINGS " + QuoteMapAndJoin(map[string]string{name: value})
+		settings, err := ParseSettings(engineFull)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := map[string]string{name: value}; !reflect.DeepEqual(settings, expected) {
+			t.Errorf("%s: expected %q, got %q", engineFull, expected, settings)
+		}
+	})
+}
+
+func FuzzParseEngineFull(f *testing.F) {
+	f.Add("ReplicatedMergeTree('/clickhouse/tables/{shard}/t', '{replica}') ORDER BY id SETTINGS index_granularity = 8192")
+	f.Add("Kafka SETTINGS kafka_broker_list = 'host1:9092, host2:9092', kafka_group_name = 'it\\'s'")
+	f.Add("ReplacingMergeTree(time]) ORDER BY id")
+	f.Add("MergeTree ORDER BY id SETTINGS x = 'unterminated\\")
+	f.Add("`")
+
+	f.Fuzz(func(t *testing.T, engineFull string) {
+		// Malformed input must be reported with an error instead of a panic.
+		_, _ = ParseEngineParams(engineFull)
+		_, _ = ParseSettings(engineFull)
+	})
+}
@@ -0,0 +1,716 @@
+package chclient
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type ClickHouseDictionaryAttribute struct {
+	Name         string
+	Type         string
+	Default      string
+	Expression   string
+	Hierarchical bool
+	Injective    bool
+}
+
+type ClickHouseDictionaryClickHouseSource struct {
+	Host            string
+	Port            uint16
+	User            string
+	Password        string
+	DB              string
+	Table           string
+	Query           string
+	Where           string
+	InvalidateQuery string
+	UpdateField     string
+	UpdateLag       uint64
+	Secure          bool
+}
+
+type ClickHouseDictionaryFileSource struct {
+	Path   string
+	Format string
+}
+
+type ClickHouseDictionaryExecutableSource struct {
+	Command     string
+	Format      string
+	ImplicitKey bool
+}
+
+type ClickHouseDictionaryHTTPSource struct {
+	URL      string
+	Format   string
+	User     string
+	Password string
+	Headers  map[string]string
+}
+
+// ClickHouseDictionarySource describes `SOURCE` clause of a dictionary. Exactly one of the fields should be set.
+type ClickHouseDictionarySource struct {
+	ClickHouse *ClickHouseDictionaryClickHouseSource
+	File       *ClickHouseDictionaryFileSource
+	Executable *ClickHouseDictionaryExecutableSource
+	HTTP       *ClickHouseDictionaryHTTPSource
+}
+
+type ClickHouseDictionary struct {
+	Database     string
+	Name         string
+	PrimaryKey   []string
+	Attributes   []ClickHouseDictionaryAttribute
+	Source       ClickHouseDictionarySource
+	Layout       string
+	LayoutParams map[string]string
+	LifetimeMin  uint64
+	LifetimeMax  uint64
+	RangeMin     string
+	RangeMax     string
+	Comment      string
+}
+
+// dictionaryParam is a single `name value` pair inside SOURCE or LAYOUT clause.
+type dictionaryParam struct {
+	name  string
+	value string
+}
+
+func joinDictionaryParams(params []dictionaryParam) string {
+	result := make([]string, 0, len(params))
+	for _, p := range params {
+		if p.value == "" {
+			continue
+		}
+		result = append(result, p.name+" "+p.value)
+	}
+	return strings.Join(result, " ")
+}
+
+func quoteNonEmpty(v string) string {
+	if v == "" {
+		return ""
+	}
+	return QuoteValue(v)
+}
+
+func (attr ClickHouseDictionaryAttribute) String() string {
+	result := fmt.Sprintf("%s %s", QuoteWithTicks(attr.Name), attr.Type)
+
+	if attr.Default != "" {
+		result += " DEFAULT " + attr.Default
+	}
+	if attr.Expression != "" {
+		result += " EXPRESSION " + attr.Expression
+	}
+	if attr.Hierarchical {
+		result += " HIERARCHICAL"
+	}
+	if attr.Injective {
+		result += " INJECTIVE"
+	}
+
+	return result
+}
+
+func (source ClickHouseDictionarySource) String() string {
+	switch {
+	case source.ClickHouse != nil:
+		s := source.ClickHouse
+		params := []dictionaryParam{
+			{"host", quoteNonEmpty(s.Host)},
+			{"user", quoteNonEmpty(s.User)},
+			{"password", quoteNonEmpty(s.Password)},
+			{"db", quoteNonEmpty(s.DB)},
+			{"table", quoteNonEmpty(s.Table)},
+			{"query", quoteNonEmpty(s.Query)},
+			{"where", quoteNonEmpty(s.Where)},
+			{"invalidate_query", quoteNonEmpty(s.InvalidateQuery)},
+			{"update_field", quoteNonEmpty(s.UpdateField)},
+		}
+		if s.Port != 0 {
+			params = append(params, dictionaryParam{"port", strconv.FormatUint(uint64(s.Port), 10)})
+		}
+		if s.UpdateLag != 0 {
+			params = append(params, dictionaryParam{"update_lag", strconv.FormatUint(s.UpdateLag, 10)})
+		}
+		if s.Secure {
+			params = append(params, dictionaryParam{"secure", "1"})
+		}
+		return "CLICKHOUSE(" + joinDictionaryParams(params) + ")"
+	case source.File != nil:
+		return "FILE(" + joinDictionaryParams([]dictionaryParam{
+			{"path", quoteNonEmpty(source.File.Path)},
+			{"format", quoteNonEmpty(source.File.Format)},
+		}) + ")"
+	case source.Executable != nil:
+		params := []dictionaryParam{
+			{"command", quoteNonEmpty(source.Executable.Command)},
+			{"format", quoteNonEmpty(source.Executable.Format)},
+		}
+		if source.Executable.ImplicitKey {
+			params = append(params, dictionaryParam{"implicit_key", "1"})
+		}
+		return "EXECUTABLE(" + joinDictionaryParams(params) + ")"
+	case source.HTTP != nil:
+		s := source.HTTP
+		params := []dictionaryParam{
+			{"url", quoteNonEmpty(s.URL)},
+			{"format", quoteNonEmpty(s.Format)},
+		}
+		if s.User != "" || s.Password != "" {
+			params = append(params, dictionaryParam{
+				"credentials",
+				"(" + joinDictionaryParams([]dictionaryParam{
+					{"user", quoteNonEmpty(s.User)},
+					{"password", quoteNonEmpty(s.Password)},
+				}) + ")",
+			})
+		}
+		if len(s.Headers) > 0 {
+			names := make([]string, 0, len(s.Headers))
+			for name := range s.Headers {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+
+			headers := make([]string, 0, len(names))
+			for _, name := range names {
+				headers = append(headers, fmt.Sprintf(
+					"header(name %s value %s)",
+					QuoteValue(name),
+					QuoteValue(s.Headers[name]),
+				))
+			}
+			params = append(params, dictionaryParam{"headers", "(" + strings.Join(headers, " ") + ")"})
+		}
+		return "HTTP(" + joinDictionaryParams(params) + ")"
+	}
+
+	return ""
+}
+
+// layoutParamValue leaves numbers as is and quotes everything else.
+func layoutParamValue(v string) string {
+	if _, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return v
+	}
+	return QuoteValue(v)
+}
+
+func (dictionary ClickHouseDictionary) layoutString() string {
+	names := make([]string, 0, len(dictionary.LayoutParams))
+	for name := range dictionary.LayoutParams {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	params := make([]dictionaryParam, 0, len(names))
+	for _, name := range names {
+		params = append(params, dictionaryParam{
+			strings.ToUpper(name),
+			layoutParamValue(dictionary.LayoutParams[name]),
+		})
+	}
+
+	return strings.ToUpper(dictionary.Layout) + "(" + joinDictionaryParams(params) + ")"
+}
+
+func (client *ClickHouseClient) CreateDictionary(ctx context.Context, dictionary ClickHouseDictionary, replace bool) error {
+	if dictionary.Source.String() == "" {
+		return fmt.Errorf("source of dictionary %s.%s is not set", dictionary.Database, dictionary.Name)
+	}
+
+	attrs := make([]string, 0, len(dictionary.Attributes))
+	for _, attr := range dictionary.Attributes {
+		attrs = append(attrs, attr.String())
+	}
+
+	replacePart := ""
+	if replace {
+		replacePart = " OR REPLACE"
+	}
+
+	query := fmt.Sprintf(
+		`CREATE%s DICTIONARY %s.%s%s
+(
+%s
+)
+PRIMARY KEY %s
+SOURCE(%s)
+LAYOUT(%s)
+LIFETIME(MIN %d MAX %d)`,
+		replacePart,
+		QuoteID(dictionary.Database),
+		QuoteID(dictionary.Name),
+		client.onCluster(),
+		strings.Join(attrs, ",\n"),
+		QuoteListWithTicksAndJoin(dictionary.PrimaryKey),
+		dictionary.Source.String(),
+		dictionary.layoutString(),
+		dictionary.LifetimeMin,
+		dictionary.LifetimeMax,
+	)
+
+	if dictionary.RangeMin != "" || dictionary.RangeMax != "" {
+		query += fmt.Sprintf(
+			"\nRANGE(MIN %s MAX %s)",
+			QuoteWithTicks(dictionary.RangeMin),
+			QuoteWithTicks(dictionary.RangeMax),
+		)
+	}
+
+	if dictionary.Comment != "" {
+		query += "\nCOMMENT " + QuoteValue(dictionary.Comment)
+	}
+
+	tflog.Info(ctx, "Creating a dictionary", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
+
+// GetDictionary reads the definition of a dictionary from create_table_query of system.tables.
+// Unlike system.dictionaries, it is available before the dictionary is loaded.
+// Passwords are hidden by ClickHouse, so they are returned empty.
+func (client *ClickHouseClient) GetDictionary(ctx context.Context, database, name string) (ClickHouseDictionary, error) {
+	query := fmt.Sprintf(
+		`SELECT "create_table_query"
+FROM "system"."tables"
+WHERE "database" = %s AND "name" = %s AND "engine" = 'Dictionary'`,
+		QuoteValue(database),
+		QuoteValue(name),
+	)
+
+	tflog.Info(ctx, "Looking for a dictionary", dict{"query": query})
+
+	rows, err := client.Conn.Query(ctx, query)
+	if err != nil {
+		return ClickHouseDictionary{}, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		return ClickHouseDictionary{}, &NotFoundError{
+			Entity: "dictionary",
+			Name:   fmt.Sprintf("%s.%s", database, name),
+			Query:  query,
+		}
+	}
+
+	var createQuery string
+	if err = rows.Scan(&createQuery); err != nil {
+		return ClickHouseDictionary{}, err
+	}
+
+	dictionary, err := parseDictionaryDefinition(createQuery)
+	if err != nil {
+		return ClickHouseDictionary{}, err
+	}
+	dictionary.Database = database
+	dictionary.Name = name
+
+	err = client.checkOnAllReplicas(
+		ctx,
+		"dictionary",
+		fmt.Sprintf("%s.%s", database, name),
+		"dictionaries",
+		fmt.Sprintf(`"database" = %s AND "name" = %s`, QuoteValue(database), QuoteValue(name)),
+	)
+	if err != nil {
+		return ClickHouseDictionary{}, err
+	}
+
+	return dictionary, nil
+}
+
+func (client *ClickHouseClient) DropDictionary(ctx context.Context, database, name string) error {
+	query := fmt.Sprintf(
+		"DROP DICTIONARY %s.%s%s SYNC",
+		QuoteID(database),
+		QuoteID(name),
+		client.onCluster(),
+	)
+
+	tflog.Info(ctx, "Dropping a dictionary", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
+
+// SameDictionaryLayout compares layout names ignoring case and underscores, e.g. `complex_key_hashed` and `COMPLEX_KEY_HASHED`.
+func SameDictionaryLayout(configured, actual string) bool {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, "_", ""))
+	}
+	return normalize(configured) == normalize(actual)
+}
+
+// parseDictionaryDefinition parses CREATE DICTIONARY query as ClickHouse formats it in create_table_query, e.g.
+// "CREATE DICTIONARY db.dict (`id` UInt64, `name` String DEFAULT 'unknown') PRIMARY KEY id
+// SOURCE(CLICKHOUSE(TABLE 'src')) LIFETIME(MIN 0 MAX 300) LAYOUT(HASHED())".
+func parseDictionaryDefinition(createQuery string) (ClickHouseDictionary, error) {
+	var dictionary ClickHouseDictionary
+
+	tokens, err := tokenizeDDL(createQuery)
+	if err != nil {
+		return dictionary, fmt.Errorf("invalid dictionary definition %q: %w", createQuery, err)
+	}
+	depths, err := ddlDepths(tokens)
+	if err != nil {
+		return dictionary, fmt.Errorf("invalid dictionary definition %q: %w", createQuery, err)
+	}
+
+	// Clauses are split by keywords on the top level. The structure is the first clause in brackets.
+	clauses := make(map[string][]int)
+	clause := ""
+	for i, token := range tokens {
+		if depths[i] == 0 && token.kind == ddlIdent {
+			keyword := strings.ToUpper(token.text)
+			switch keyword {
+			case "PRIMARY", "SOURCE", "LIFETIME", "LAYOUT", "RANGE", "SETTINGS", "COMMENT":
+				clause = keyword
+				continue
+			case "KEY":
+				if clause == "PRIMARY" && len(clauses[clause]) == 0 {
+					continue
+				}
+			}
+		}
+		if clause == "" && depths[i] == 0 && token.isPunct("(") {
+			clause = "STRUCTURE"
+			continue
+		}
+		if clause != "" {
+			clauses[clause] = append(clauses[clause], i)
+		}
+	}
+
+	part := func(name string) ([]ddlToken, []int) {
+		indexes := clauses[name]
+		if len(indexes) == 0 {
+			return nil, nil
+		}
+		return tokens[indexes[0] : indexes[len(indexes)-1]+1], depths[indexes[0] : indexes[len(indexes)-1]+1]
+	}
+	invalid := func(clause string) error {
+		return fmt.Errorf("invalid dictionary definition %q: cannot parse %s clause", createQuery, clause)
+	}
+
+	structure, structureDepths := part("STRUCTURE")
+	if len(structure) < 2 || !structure[len(structure)-1].isPunct(")") {
+		return dictionary, invalid("structure")
+	}
+	for _, attrTokens := range splitDDLTokens(structure[:len(structure)-1], structureDepths[:len(structure)-1], 1) {
+		attr, ok := parseDictionaryAttribute(createQuery, attrTokens)
+		if !ok {
+			return dictionary, invalid("structure")
+		}
+		dictionary.Attributes = append(dictionary.Attributes, attr)
+	}
+
+	key, keyDepths := part("PRIMARY")
+	for _, keyTokens := range splitDDLTokens(key, keyDepths, 0) {
+		if len(keyTokens) == 0 {
+			return dictionary, invalid("PRIMARY KEY")
+		}
+		dictionary.PrimaryKey = append(dictionary.PrimaryKey, ddlName(createQuery, keyTokens))
+	}
+
+	if source, sourceDepths := part("SOURCE"); len(source) > 0 {
+		sourceType, params, ok := parseDictionaryFunction(createQuery, source, sourceDepths)
+		if !ok {
+			return dictionary, invalid("SOURCE")
+		}
+		dictionary.Source, ok = dictionarySourceFromParams(sourceType, params)
+		if !ok {
+			return dictionary, invalid("SOURCE")
+		}
+	}
+
+	if layout, layoutDepths := part("LAYOUT"); len(layout) > 0 {
+		layoutType, params, ok := parseDictionaryFunction(createQuery, layout, layoutDepths)
+		if !ok {
+			return dictionary, invalid("LAYOUT")
+		}
+		dictionary.Layout = layoutType
+		if len(params) > 0 {
+			dictionary.LayoutParams = make(map[string]string, len(params))
+			for name, value := range params {
+				dictionary.LayoutParams[name] = value.text
+			}
+		}
+	}
+
+	if lifetime, lifetimeDepths := part("LIFETIME"); len(lifetime) > 0 {
+		params, ok := parseDictionaryParams(createQuery, lifetime, lifetimeDepths)
+		if !ok {
+			return dictionary, invalid("LIFETIME")
+		}
+		if len(params) == 0 && len(lifetime) == 3 {
+			// LIFETIME(300) is the same as LIFETIME(MIN 0 MAX 300).
+			params = map[string]dictionaryValue{"max": {text: lifetime[1].text}}
+		}
+		for name, target := range map[string]*uint64{"min": &dictionary.LifetimeMin, "max": &dictionary.LifetimeMax} {
+			if value, found := params[name]; found {
+				if *target, err = strconv.ParseUint(value.text, 10, 64); err != nil {
+					return dictionary, invalid("LIFETIME")
+				}
+			}
+		}
+	}
+
+	if dictionaryRange, rangeDepths := part("RANGE"); len(dictionaryRange) > 0 {
+		params, ok := parseDictionaryParams(createQuery, dictionaryRange, rangeDepths)
+		if !ok {
+			return dictionary, invalid("RANGE")
+		}
+		dictionary.RangeMin = params["min"].text
+		dictionary.RangeMax = params["max"].text
+	}
+
+	if comment, _ := part("COMMENT"); len(comment) > 0 {
+		if comment[0].kind != ddlString {
+			return dictionary, invalid("COMMENT")
+		}
+		dictionary.Comment = comment[0].text
+	}
+
+	return dictionary, nil
+}
+
+// ddlName returns an unquoted name or the raw expression.
+func ddlName(s string, tokens []ddlToken) string {
+	if len(tokens) == 1 && (tokens[0].kind == ddlQuotedIdent || tokens[0].kind == ddlIdent) {
+		return tokens[0].text
+	}
+	return rawDDL(s, tokens)
+}
+
+// parseDictionaryAttribute parses an element of the dictionary structure, e.g. "`name` String DEFAULT 'unknown' INJECTIVE".
+func parseDictionaryAttribute(s string, tokens []ddlToken) (ClickHouseDictionaryAttribute, bool) {
+	var attr ClickHouseDictionaryAttribute
+	if len(tokens) < 2 || (tokens[0].kind != ddlQuotedIdent && tokens[0].kind != ddlIdent) {
+		return attr, false
+	}
+	attr.Name = tokens[0].text
+
+	depths, err := ddlDepths(tokens)
+	if err != nil {
+		return attr, false
+	}
+
+	// Every part of the attribute ends where the next keyword starts.
+	part := "TYPE"
+	start := 1
+	flush := func(end int) bool {
+		if start >= end {
+			return part == "HIERARCHICAL" || part == "INJECTIVE" || part == "IS_OBJECT_ID"
+		}
+		value := rawDDL(s, tokens[start:end])
+		switch part {
+		case "TYPE":
+			attr.Type = value
+		case "DEFAULT":
+			attr.Default = value
+		case "EXPRESSION":
+			attr.Expression = value
+		default:
+			return false
+		}
+		return true
+	}
+	for i := 1; i <= len(tokens); i++ {
+		keyword := ""
+		if i < len(tokens) && depths[i] == 0 && tokens[i].kind == ddlIdent {
+			keyword = strings.ToUpper(tokens[i].text)
+		}
+		switch keyword {
+		case "DEFAULT", "EXPRESSION", "HIERARCHICAL", "INJECTIVE", "IS_OBJECT_ID":
+		default:
+			if i < len(tokens) {
+				continue
+			}
+		}
+		if !flush(i) {
+			return attr, false
+		}
+		switch keyword {
+		case "HIERARCHICAL":
+			attr.Hierarchical = true
+		case "INJECTIVE":
+			attr.Injective = true
+		}
+		part = keyword
+		start = i + 1
+	}
+
+	return attr, attr.Type != ""
+}
+
+// dictionaryValue is a value of a parameter inside SOURCE, LAYOUT, LIFETIME or RANGE clause.
+// Strings are unquoted, nested parameters like CREDENTIALS(USER 'u') are stored in params.
+type dictionaryValue struct {
+	text   string
+	params []map[string]dictionaryValue
+}
+
+// parseDictionaryFunction parses a clause like `(CLICKHOUSE(HOST 'h' PORT 9000))` into the function name and parameters.
+func parseDictionaryFunction(s string, tokens []ddlToken, depths []int) (string, map[string]dictionaryValue, bool) {
+	if len(tokens) < 5 || !tokens[0].isPunct("(") || tokens[1].kind != ddlIdent || !tokens[2].isPunct("(") {
+		return "", nil, false
+	}
+	params, ok := parseDictionaryParams(s, tokens[2:len(tokens)-1], depths[2:len(tokens)-1])
+	return tokens[1].text, params, ok
+}
+
+// parseDictionaryParams parses `(NAME value NAME value ...)` where tokens start with the opening bracket
+// and depths are their nesting levels. Names are lowercased.
+func parseDictionaryParams(s string, tokens []ddlToken, depths []int) (map[string]dictionaryValue, bool) {
+	if len(tokens) < 2 || !tokens[0].isPunct("(") || !tokens[len(tokens)-1].isPunct(")") {
+		return nil, false
+	}
+	tokens, depths = tokens[1:len(tokens)-1], depths[1:len(depths)-1]
+
+	// closing returns the index of the bracket closing the one at i.
+	closing := func(i int) int {
+		for j := i + 1; j < len(tokens); j++ {
+			if depths[j] == depths[i] && tokens[j].isPunct(")") {
+				return j
+			}
+		}
+		return -1
+	}
+
+	params := make(map[string]dictionaryValue)
+	for i := 0; i < len(tokens); {
+		if tokens[i].kind != ddlIdent || i+1 >= len(tokens) {
+			// LIFETIME(300) has a value without a name.
+			return map[string]dictionaryValue{}, len(tokens) == 1 && tokens[0].kind == ddlNumber
+		}
+		name := strings.ToLower(tokens[i].text)
+		i++
+
+		var value dictionaryValue
+		if tokens[i].isPunct("(") {
+			// Nested parameters, e.g. CREDENTIALS(USER 'u') or HEADERS(HEADER(NAME 'n' VALUE 'v')).
+			end := closing(i)
+			if end == -1 {
+				return nil, false
+			}
+			nested, ok := parseNestedDictionaryParams(s, tokens[i:end+1], depths[i:end+1])
+			if !ok {
+				return nil, false
+			}
+			value.params = nested
+			i = end + 1
+		} else {
+			end := i
+			if end+1 < len(tokens) && tokens[end+1].isPunct("(") {
+				// Function calls as values, e.g. PORT tcpPort().
+				if end = closing(end + 1); end == -1 {
+					return nil, false
+				}
+			}
+			value.text = tokens[i].text
+			if end > i {
+				value.text = rawDDL(s, tokens[i:end+1])
+			}
+			i = end + 1
+		}
+		params[name] = value
+	}
+
+	return params, true
+}
+
+// parseNestedDictionaryParams parses either `(NAME value ...)` or a list of functions like `(HEADER(...) HEADER(...))`.
+func parseNestedDictionaryParams(s string, tokens []ddlToken, depths []int) ([]map[string]dictionaryValue, bool) {
+	if len(tokens) > 3 && tokens[1].kind == ddlIdent && tokens[2].isPunct("(") {
+		var result []map[string]dictionaryValue
+		for i := 1; i < len(tokens)-1; {
+			if tokens[i].kind != ddlIdent || !tokens[i+1].isPunct("(") {
+				return nil, false
+			}
+			end := i + 2
+			for end < len(tokens) && !(depths[end] == depths[i+1] && tokens[end].isPunct(")")) {
+				end++
+			}
+			params, ok := parseDictionaryParams(s, tokens[i+1:end+1], depths[i+1:end+1])
+			if !ok {
+				return nil, false
+			}
+			result = append(result, params)
+			i = end + 1
+		}
+		return result, true
+	}
+
+	params, ok := parseDictionaryParams(s, tokens, depths)
+	return []map[string]dictionaryValue{params}, ok
+}
+
+func dictionarySourceFromParams(sourceType string, params map[string]dictionaryValue) (ClickHouseDictionarySource, bool) {
+	var source ClickHouseDictionarySource
+	number := func(name string) (uint64, bool) {
+		value, found := params[name]
+		if !found {
+			return 0, true
+		}
+		n, err := strconv.ParseUint(value.text, 10, 64)
+		return n, err == nil
+	}
+	flag := func(name string) bool {
+		value := params[name].text
+		return value == "1" || strings.EqualFold(value, "true")
+	}
+
+	switch strings.ToUpper(sourceType) {
+	case "CLICKHOUSE":
+		port, portOk := number("port")
+		updateLag, updateLagOk := number("update_lag")
+		if !portOk || !updateLagOk || port > 65535 {
+			return source, false
+		}
+		source.ClickHouse = &ClickHouseDictionaryClickHouseSource{
+			Host:            params["host"].text,
+			Port:            uint16(port),
+			User:            params["user"].text,
+			DB:              params["db"].text,
+			Table:           params["table"].text,
+			Query:           params["query"].text,
+			Where:           params["where"].text,
+			InvalidateQuery: params["invalidate_query"].text,
+			UpdateField:     params["update_field"].text,
+			UpdateLag:       updateLag,
+			Secure:          flag("secure"),
+		}
+	case "FILE":
+		source.File = &ClickHouseDictionaryFileSource{Path: params["path"].text, Format: params["format"].text}
+	case "EXECUTABLE":
+		source.Executable = &ClickHouseDictionaryExecutableSource{
+			Command:     params["command"].text,
+			Format:      params["format"].text,
+			ImplicitKey: flag("implicit_key"),
+		}
+	case "HTTP":
+		source.HTTP = &ClickHouseDictionaryHTTPSource{URL: params["url"].text, Format: params["format"].text}
+		for _, credentials := range params["credentials"].params {
+			source.HTTP.User = credentials["user"].text
+		}
+		for _, header := range params["headers"].params {
+			if source.HTTP.Headers == nil {
+				source.HTTP.Headers = make(map[string]string)
+			}
+			source.HTTP.Headers[header["name"].text] = header["value"].text
+		}
+	default:
+		return source, false
+	}
+
+	return source, true
+}
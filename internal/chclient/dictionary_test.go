@@ -0,0 +1,271 @@
+package chclient
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestCreateDictionarySQL(t *testing.T) {
+	testCases := []struct {
+		name          string
+		dictionary    ClickHouseDictionary
+		replace       bool
+		expectedQuery string
+	}{
+		{
+			name: "ClickHouse source",
+			dictionary: ClickHouseDictionary{
+				Database:   "db",
+				Name:       "dict",
+				PrimaryKey: []string{"id"},
+				Attributes: []ClickHouseDictionaryAttribute{
+					{Name: "id", Type: "UInt64"},
+					{Name: "parent_id", Type: "UInt64", Default: "0", Hierarchical: true},
+					{Name: "name", Type: "String", Default: "''", Injective: true},
+				},
+				Source: ClickHouseDictionarySource{
+					ClickHouse: &ClickHouseDictionaryClickHouseSource{DB: "db", Table: "src", Port: 9000},
+				},
+				Layout:       "hashed",
+				LayoutParams: map[string]string{"shards": "16"},
+				LifetimeMin:  300,
+				LifetimeMax:  600,
+				Comment:      "my dict",
+			},
+			expectedQuery: "CREATE DICTIONARY \"db\".\"dict\"\n(\n" +
+				"`id` UInt64,\n" +
+				"`parent_id` UInt64 DEFAULT 0 HIERARCHICAL,\n" +
+				"`name` String DEFAULT '' INJECTIVE\n" +
+				")\nPRIMARY KEY `id`\n" +
+				"SOURCE(CLICKHOUSE(db 'db' table 'src' port 9000))\n" +
+				"LAYOUT(HASHED(SHARDS 16))\n" +
+				"LIFETIME(MIN 300 MAX 600)\n" +
+				"COMMENT 'my dict'",
+		},
+		{
+			name:    "HTTP source with range",
+			replace: true,
+			dictionary: ClickHouseDictionary{
+				Database:   "db",
+				Name:       "dict",
+				PrimaryKey: []string{"id"},
+				Attributes: []ClickHouseDictionaryAttribute{
+					{Name: "id", Type: "UInt64"},
+					{Name: "start", Type: "Date"},
+					{Name: "end", Type: "Date"},
+				},
+				Source: ClickHouseDictionarySource{
+					HTTP: &ClickHouseDictionaryHTTPSource{
+						URL:     "http://example.com/data",
+						Format:  "TSV",
+						User:    "u",
+						Headers: map[string]string{"X-Key": "secret"},
+					},
+				},
+				Layout:   "RANGE_HASHED",
+				RangeMin: "start",
+				RangeMax: "end",
+			},
+			expectedQuery: "CREATE OR REPLACE DICTIONARY \"db\".\"dict\"\n(\n" +
+				"`id` UInt64,\n`start` Date,\n`end` Date\n" +
+				")\nPRIMARY KEY `id`\n" +
+				"SOURCE(HTTP(url 'http://example.com/data' format 'TSV' credentials (user 'u') " +
+				"headers (header(name 'X-Key' value 'secret'))))\n" +
+				"LAYOUT(RANGE_HASHED())\n" +
+				"LIFETIME(MIN 0 MAX 0)\n" +
+				"RANGE(MIN `start` MAX `end`)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			conn := mock_driver.NewMockConn(mockCtrl)
+			conn.EXPECT().Exec(ctx, tc.expectedQuery).Return(nil).Times(1)
+
+			client := &ClickHouseClient{Conn: conn}
+			if err := client.CreateDictionary(ctx, tc.dictionary, tc.replace); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestSameDictionaryLayout(t *testing.T) {
+	testCases := []struct {
+		configured string
+		actual     string
+		same       bool
+	}{
+		{configured: "HASHED", actual: "HASHED", same: true},
+		{configured: "complex_key_hashed", actual: "COMPLEX_KEY_HASHED", same: true},
+		{configured: "RANGE_HASHED", actual: "range_hashed", same: true},
+		{configured: "HASHED", actual: "SPARSE_HASHED", same: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.configured, func(t *testing.T) {
+			if actual := SameDictionaryLayout(tc.configured, tc.actual); actual != tc.same {
+				t.Errorf("expected %v for %q and %q, got %v", tc.same, tc.configured, tc.actual, actual)
+			}
+		})
+	}
+}
+
+func TestParseDictionaryDefinition(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		expected ClickHouseDictionary
+	}{
+		{
+			name: "ClickHouse source",
+			query: "CREATE DICTIONARY db.dict (`id` UInt64, `parent_id` UInt64 DEFAULT 0 HIERARCHICAL, " +
+				"`name` String DEFAULT 'unknown' INJECTIVE, `upper` String EXPRESSION upper(name)) PRIMARY KEY id " +
+				"SOURCE(CLICKHOUSE(HOST 'localhost' PORT 9000 USER 'default' PASSWORD '[HIDDEN]' DB 'db' TABLE 'src' " +
+				"UPDATE_LAG 15 SECURE 1)) LIFETIME(MIN 300 MAX 600) LAYOUT(HASHED(SHARDS 16)) COMMENT 'my dict'",
+			expected: ClickHouseDictionary{
+				PrimaryKey: []string{"id"},
+				Attributes: []ClickHouseDictionaryAttribute{
+					{Name: "id", Type: "UInt64"},
+					{Name: "parent_id", Type: "UInt64", Default: "0", Hierarchical: true},
+					{Name: "name", Type: "String", Default: "'unknown'", Injective: true},
+					{Name: "upper", Type: "String", Expression: "upper(name)"},
+				},
+				Source: ClickHouseDictionarySource{
+					ClickHouse: &ClickHouseDictionaryClickHouseSource{
+						Host: "localhost", Port: 9000, User: "default", DB: "db", Table: "src", UpdateLag: 15, Secure: true,
+					},
+				},
+				Layout:       "HASHED",
+				LayoutParams: map[string]string{"shards": "16"},
+				LifetimeMin:  300,
+				LifetimeMax:  600,
+				Comment:      "my dict",
+			},
+		},
+		{
+			name: "HTTP source with range",
+			query: "CREATE DICTIONARY db.dict (`id` UInt64, `start` Date, `end` Nullable(Date)) PRIMARY KEY id " +
+				"SOURCE(HTTP(URL 'http://example.com/data' FORMAT 'TSV' CREDENTIALS(USER 'u' PASSWORD '[HIDDEN]') " +
+				"HEADERS(HEADER(NAME 'X-Key' VALUE 'secret') HEADER(NAME 'X-Other' VALUE 'value')))) " +
+				"LIFETIME(300) LAYOUT(RANGE_HASHED()) RANGE(MIN start MAX `end`)",
+			expected: ClickHouseDictionary{
+				PrimaryKey: []string{"id"},
+				Attributes: []ClickHouseDictionaryAttribute{
+					{Name: "id", Type: "UInt64"},
+					{Name: "start", Type: "Date"},
+					{Name: "end", Type: "Nullable(Date)"},
+				},
+				Source: ClickHouseDictionarySource{
+					HTTP: &ClickHouseDictionaryHTTPSource{
+						URL:     "http://example.com/data",
+						Format:  "TSV",
+						User:    "u",
+						Headers: map[string]string{"X-Key": "secret", "X-Other": "value"},
+					},
+				},
+				Layout:      "RANGE_HASHED",
+				LifetimeMax: 300,
+				RangeMin:    "start",
+				RangeMax:    "end",
+			},
+		},
+		{
+			name: "Complex key",
+			query: "CREATE DICTIONARY db.dict (`a` String, `b` UInt8, `v` Float64) PRIMARY KEY a, b " +
+				"SOURCE(FILE(PATH '/var/lib/clickhouse/user_files/d.tsv' FORMAT 'TabSeparated')) " +
+				"LIFETIME(MIN 0 MAX 0) LAYOUT(COMPLEX_KEY_HASHED())",
+			expected: ClickHouseDictionary{
+				PrimaryKey: []string{"a", "b"},
+				Attributes: []ClickHouseDictionaryAttribute{
+					{Name: "a", Type: "String"},
+					{Name: "b", Type: "UInt8"},
+					{Name: "v", Type: "Float64"},
+				},
+				Source: ClickHouseDictionarySource{
+					File: &ClickHouseDictionaryFileSource{Path: "/var/lib/clickhouse/user_files/d.tsv", Format: "TabSeparated"},
+				},
+				Layout: "COMPLEX_KEY_HASHED",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := parseDictionaryDefinition(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseDictionaryDefinitionErrors(t *testing.T) {
+	for _, query := range []string{
+		"CREATE DICTIONARY db.dict PRIMARY KEY id SOURCE(CLICKHOUSE(TABLE 'src')) LAYOUT(FLAT())",
+		"CREATE DICTIONARY db.dict (`id`) PRIMARY KEY id SOURCE(CLICKHOUSE(TABLE 'src')) LAYOUT(FLAT())",
+		"CREATE DICTIONARY db.dict (`id` UInt64) PRIMARY KEY id SOURCE(MONGODB(HOST 'h')) LAYOUT(FLAT())",
+		"CREATE DICTIONARY db.dict (`id` UInt64) PRIMARY KEY id SOURCE(CLICKHOUSE(PORT 'x')) LAYOUT(FLAT())",
+		"CREATE DICTIONARY db.dict (`id` UInt64) PRIMARY KEY id SOURCE(CLICKHOUSE(TABLE 'src') LAYOUT(FLAT())",
+	} {
+		if _, err := parseDictionaryDefinition(query); err == nil {
+			t.Errorf("expected error for %q", query)
+		}
+	}
+}
+
+func TestCreateDictionaryRoundTrip(t *testing.T) {
+	dictionary := ClickHouseDictionary{
+		Database:   "db",
+		Name:       "dict",
+		PrimaryKey: []string{"id"},
+		Attributes: []ClickHouseDictionaryAttribute{
+			{Name: "id", Type: "UInt64"},
+			{Name: "name", Type: "String", Default: "'it''s'", Injective: true},
+		},
+		Source: ClickHouseDictionarySource{
+			ClickHouse: &ClickHouseDictionaryClickHouseSource{DB: "db", Table: "src", Where: "id > 0", Port: 9000},
+		},
+		Layout:       "HASHED",
+		LayoutParams: map[string]string{"shards": "16"},
+		LifetimeMin:  10,
+		LifetimeMax:  20,
+		Comment:      "it's a dictionary",
+	}
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var createQuery string
+	conn := mock_driver.NewMockConn(mockCtrl)
+	conn.EXPECT().Exec(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, query string, _ ...any) error {
+		createQuery = query
+		return nil
+	}).Times(1)
+
+	client := &ClickHouseClient{Conn: conn}
+	if err := client.CreateDictionary(ctx, dictionary, false); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := parseDictionaryDefinition(createQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual.Database, actual.Name = dictionary.Database, dictionary.Name
+	if !reflect.DeepEqual(actual, dictionary) {
+		t.Errorf("expected %+v, got %+v", dictionary, actual)
+	}
+}
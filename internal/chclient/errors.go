@@ -0,0 +1,207 @@
+package chclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+// exceptionNames maps codes of ClickHouse exceptions which are classified into typed errors or retried to their names.
+// See: https://github.com/ClickHouse/ClickHouse/blob/master/src/Common/ErrorCodes.cpp
+var exceptionNames = map[int32]string{
+	57:  "TABLE_ALREADY_EXISTS",
+	60:  "UNKNOWN_TABLE",
+	81:  "UNKNOWN_DATABASE",
+	82:  "DATABASE_ALREADY_EXISTS",
+	159: "TIMEOUT_EXCEEDED",
+	164: "READONLY",
+	192: "UNKNOWN_USER",
+	202: "TOO_MANY_SIMULTANEOUS_QUERIES",
+	209: "SOCKET_TIMEOUT",
+	210: "NETWORK_ERROR",
+	225: "NO_ZOOKEEPER",
+	242: "TABLE_IS_READ_ONLY",
+	473: "DEADLOCK_AVOIDED",
+	492: "ACCESS_ENTITY_NOT_FOUND",
+	493: "ACCESS_ENTITY_ALREADY_EXISTS",
+	497: "ACCESS_DENIED",
+	511: "UNKNOWN_ROLE",
+	516: "AUTHENTICATION_FAILED",
+	517: "CANNOT_ASSIGN_ALTER",
+	999: "KEEPER_EXCEPTION",
+}
+
+// httpExceptionRegexp matches exceptions reported in HTTP response bodies,
+// e.g. `Code: 60. DB::Exception: Table default.t does not exist. (UNKNOWN_TABLE) (version 23.12.1.1)`.
+var httpExceptionRegexp = regexp.MustCompile(`(?s)Code: (\d+)\. DB::Exception: (.*?)\s*(?:\(version [^)]*\))?\s*$`)
+
+// exceptionNameRegexp matches the exception name which servers append to messages, e.g. `(UNKNOWN_TABLE)`.
+var exceptionNameRegexp = regexp.MustCompile(`\(([A-Z][A-Z0-9_]+)\)$`)
+
+// ExceptionError is an exception raised by ClickHouse server.
+type ExceptionError struct {
+	Code    int32
+	Name    string
+	Message string
+	Err     error
+}
+
+// UnknownEntityError is returned when a query refers to a database, table or access entity that does not exist.
+type UnknownEntityError struct {
+	*ExceptionError
+	Entity string
+}
+
+// AlreadyExistsError is returned when a database, table or access entity to create already exists.
+type AlreadyExistsError struct {
+	*ExceptionError
+	Entity string
+}
+
+// AccessDeniedError is returned when the user is not authenticated or is not allowed to run a query.
+type AccessDeniedError struct {
+	*ExceptionError
+}
+
+// TimeoutError is returned when a query is not finished in time.
+type TimeoutError struct {
+	*ExceptionError
+}
+
+// KeeperError is returned when ClickHouse Keeper (ZooKeeper) is unavailable or fails to process a query.
+type KeeperError struct {
+	*ExceptionError
+}
+
+func (e *ExceptionError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("code: %d, message: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("code: %d (%s), message: %s", e.Code, e.Name, e.Message)
+}
+
+func (e *ExceptionError) Unwrap() error     { return e.Err }
+func (e *UnknownEntityError) Unwrap() error { return e.ExceptionError }
+func (e *AlreadyExistsError) Unwrap() error { return e.ExceptionError }
+func (e *AccessDeniedError) Unwrap() error  { return e.ExceptionError }
+func (e *TimeoutError) Unwrap() error       { return e.ExceptionError }
+func (e *KeeperError) Unwrap() error        { return e.ExceptionError }
+func (e *ExceptionError) error()            {}
+func (e *UnknownEntityError) error()        {}
+func (e *AlreadyExistsError) error()        {}
+func (e *AccessDeniedError) error()         {}
+func (e *TimeoutError) error()              {}
+func (e *KeeperError) error()               {}
+
+var _ ClickHouseClientError = &ExceptionError{}
+var _ ClickHouseClientError = &UnknownEntityError{}
+var _ ClickHouseClientError = &AlreadyExistsError{}
+var _ ClickHouseClientError = &AccessDeniedError{}
+var _ ClickHouseClientError = &TimeoutError{}
+var _ ClickHouseClientError = &KeeperError{}
+
+// IsNotFound reports whether err means that the requested entity does not exist.
+func IsNotFound(err error) bool {
+	var notFoundError *NotFoundError
+	var unknownEntityError *UnknownEntityError
+	return errors.As(err, &notFoundError) || errors.As(err, &unknownEntityError)
+}
+
+// classifyError converts ClickHouse exceptions into typed errors. Other errors are returned as is.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var classified ClickHouseClientError
+	if errors.As(err, &classified) {
+		return err
+	}
+
+	exception := newExceptionError(err)
+	if exception == nil {
+		return err
+	}
+
+	switch exception.Name {
+	case "UNKNOWN_TABLE":
+		return &UnknownEntityError{ExceptionError: exception, Entity: "table"}
+	case "UNKNOWN_DATABASE":
+		return &UnknownEntityError{ExceptionError: exception, Entity: "database"}
+	case "UNKNOWN_USER":
+		return &UnknownEntityError{ExceptionError: exception, Entity: "user"}
+	case "UNKNOWN_ROLE":
+		return &UnknownEntityError{ExceptionError: exception, Entity: "role"}
+	case "ACCESS_ENTITY_NOT_FOUND":
+		return &UnknownEntityError{ExceptionError: exception, Entity: "access entity"}
+	case "TABLE_ALREADY_EXISTS":
+		return &AlreadyExistsError{ExceptionError: exception, Entity: "table"}
+	case "DATABASE_ALREADY_EXISTS":
+		return &AlreadyExistsError{ExceptionError: exception, Entity: "database"}
+	case "ACCESS_ENTITY_ALREADY_EXISTS":
+		return &AlreadyExistsError{ExceptionError: exception, Entity: "access entity"}
+	case "ACCESS_DENIED", "AUTHENTICATION_FAILED", "READONLY":
+		return &AccessDeniedError{ExceptionError: exception}
+	case "TIMEOUT_EXCEEDED", "SOCKET_TIMEOUT":
+		return &TimeoutError{ExceptionError: exception}
+	case "KEEPER_EXCEPTION", "NO_ZOOKEEPER", "TABLE_IS_READ_ONLY":
+		return &KeeperError{ExceptionError: exception}
+	default:
+		return exception
+	}
+}
+
+// newExceptionError extracts a ClickHouse exception from err or returns nil if err is not an exception.
+func newExceptionError(err error) *ExceptionError {
+	exception := &ExceptionError{Err: err}
+
+	var chException *clickhouse.Exception
+	if errors.As(err, &chException) {
+		exception.Code = chException.Code
+		exception.Message = chException.Message
+	} else {
+		// The HTTP interface reports exceptions in the response body only.
+		matches := httpExceptionRegexp.FindStringSubmatch(err.Error())
+		if matches == nil {
+			return nil
+		}
+		code, parseErr := strconv.ParseInt(matches[1], 10, 32)
+		if parseErr != nil {
+			return nil
+		}
+		exception.Code = int32(code)
+		exception.Message = matches[2]
+	}
+
+	exception.Name = exceptionNames[exception.Code]
+	if matches := exceptionNameRegexp.FindStringSubmatch(exception.Message); matches != nil && exception.Name == "" {
+		exception.Name = matches[1]
+	}
+	return exception
+}
+
+// exceptionConn converts ClickHouse exceptions returned by the underlying connection into typed errors.
+type exceptionConn struct {
+	driver.Conn
+}
+
+func (c *exceptionConn) Ping(ctx context.Context) error {
+	return classifyError(c.Conn.Ping(ctx))
+}
+
+func (c *exceptionConn) Exec(ctx context.Context, query string, args ...any) error {
+	return classifyError(c.Conn.Exec(ctx, query, args...))
+}
+
+func (c *exceptionConn) Query(ctx context.Context, query string, args ...any) (driver.Rows, error) {
+	rows, err := c.Conn.Query(ctx, query, args...)
+	return rows, classifyError(err)
+}
+
+func (c *exceptionConn) Select(ctx context.Context, dest any, query string, args ...any) error {
+	return classifyError(c.Conn.Select(ctx, dest, query, args...))
+}
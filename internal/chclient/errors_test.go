@@ -0,0 +1,141 @@
+package chclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestClassifyError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected func(err error) bool
+		code     int32
+	}{
+		{
+			name: "Unknown table over native protocol",
+			err:  &clickhouse.Exception{Code: 60, Name: "DB::Exception", Message: "Table default.t does not exist. (UNKNOWN_TABLE)"},
+			expected: func(err error) bool {
+				var e *UnknownEntityError
+				return errors.As(err, &e) && e.Entity == "table" && IsNotFound(err)
+			},
+			code: 60,
+		},
+		{
+			name: "Unknown database over HTTP",
+			err: errors.New("clickhouse [execute]:: 404 code: Code: 81. DB::Exception: Database db does not exist. " +
+				"(UNKNOWN_DATABASE) (version 23.12.1.1)\n"),
+			expected: func(err error) bool {
+				var e *UnknownEntityError
+				return errors.As(err, &e) && e.Entity == "database" && e.Message == "Database db does not exist. (UNKNOWN_DATABASE)"
+			},
+			code: 81,
+		},
+		{
+			name: "Table already exists",
+			err:  fmt.Errorf("wrapped: %w", &clickhouse.Exception{Code: 57, Message: "Table default.t already exists"}),
+			expected: func(err error) bool {
+				var e *AlreadyExistsError
+				return errors.As(err, &e) && e.Entity == "table"
+			},
+			code: 57,
+		},
+		{
+			name: "Access denied",
+			err:  &clickhouse.Exception{Code: 497, Message: "user: Not enough privileges"},
+			expected: func(err error) bool {
+				var e *AccessDeniedError
+				return errors.As(err, &e)
+			},
+			code: 497,
+		},
+		{
+			name: "Timeout exceeded",
+			err:  &clickhouse.Exception{Code: 159, Message: "Timeout exceeded: elapsed 60.1 seconds"},
+			expected: func(err error) bool {
+				var e *TimeoutError
+				return errors.As(err, &e)
+			},
+			code: 159,
+		},
+		{
+			name: "Keeper exception",
+			err:  &clickhouse.Exception{Code: 999, Message: "Coordination error: Connection loss"},
+			expected: func(err error) bool {
+				var e *KeeperError
+				return errors.As(err, &e)
+			},
+			code: 999,
+		},
+		{
+			name: "Other exception keeps its name",
+			err:  &clickhouse.Exception{Code: 62, Message: "Syntax error: failed at position 1. (SYNTAX_ERROR)"},
+			expected: func(err error) bool {
+				var e *ExceptionError
+				return errors.As(err, &e) && e.Name == "SYNTAX_ERROR" && !IsNotFound(err)
+			},
+			code: 62,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := classifyError(tc.err)
+			if !tc.expected(err) {
+				t.Errorf("unexpected classification: %#v", err)
+			}
+
+			var exception *ExceptionError
+			if !errors.As(err, &exception) || exception.Code != tc.code {
+				t.Errorf("expected exception with code %d, got %v", tc.code, err)
+			}
+			if !errors.Is(err, tc.err) {
+				t.Errorf("original error is not wrapped: %v", err)
+			}
+		})
+	}
+}
+
+func TestClassifyErrorKeepsOtherErrors(t *testing.T) {
+	for _, err := range []error{
+		nil,
+		context.DeadlineExceeded,
+		errors.New("dial tcp 127.0.0.1:9000: connect: connection refused"),
+		&NotFoundError{Entity: "table", Name: "db.t"},
+	} {
+		if actual := classifyError(err); actual != err {
+			t.Errorf("expected %v, got %v", err, actual)
+		}
+	}
+}
+
+func TestExceptionConn(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	conn := mock_driver.NewMockConn(mockCtrl)
+	conn.EXPECT().Exec(ctx, `DROP TABLE "db"."t"`).
+		Return(&clickhouse.Exception{Code: 60, Message: "Table db.t does not exist"}).Times(1)
+	conn.EXPECT().Query(ctx, `SELECT 1`).
+		Return(nil, &clickhouse.Exception{Code: 497, Message: "Not enough privileges"}).Times(1)
+
+	client := ClickHouseClient{Conn: &exceptionConn{Conn: conn}}
+
+	err := client.Conn.Exec(ctx, `DROP TABLE "db"."t"`)
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	_, err = client.Conn.Query(ctx, `SELECT 1`)
+	var accessDeniedError *AccessDeniedError
+	if !errors.As(err, &accessDeniedError) {
+		t.Errorf("expected access denied error, got %v", err)
+	}
+}
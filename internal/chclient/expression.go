@@ -0,0 +1,65 @@
+package chclient
+
+import (
+	"regexp"
+	"strings"
+)
+
+var intervalLiteralRegexp = regexp.MustCompile(
+	`(?i)\bINTERVAL\s+(\d+)\s+(NANOSECOND|MICROSECOND|MILLISECOND|SECOND|MINUTE|HOUR|DAY|WEEK|MONTH|QUARTER|YEAR)S?\b`,
+)
+
+var quotedIdentifierRegexp = regexp.MustCompile("`([A-Za-z_][A-Za-z0-9_]*)`")
+
+// normalizeExpression brings an SQL expression closer to the form ClickHouse uses when it formats queries:
+// `INTERVAL 1 DAY` becomes `toIntervalDay(1)`, quotes around plain identifiers and whitespace are removed.
+func normalizeExpression(expr string) string {
+	expr = quotedIdentifierRegexp.ReplaceAllString(expr, "$1")
+	expr = intervalLiteralRegexp.ReplaceAllStringFunc(expr, func(match string) string {
+		parts := intervalLiteralRegexp.FindStringSubmatch(match)
+		unit := strings.ToUpper(parts[2][:1]) + strings.ToLower(parts[2][1:])
+		return "toInterval" + unit + "(" + parts[1] + ")"
+	})
+	return strings.Join(strings.Fields(expr), "")
+}
+
+// SameExpression compares SQL expressions ignoring differences introduced by ClickHouse when it formats queries.
+func SameExpression(configured, actual string) bool {
+	return normalizeExpression(configured) == normalizeExpression(actual)
+}
+
+// SameExpressions compares lists of SQL expressions, e.g. elements of ORDER BY, with SameExpression.
+func SameExpressions(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !SameExpression(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// SameCodec compares column codecs, e.g. `Delta, ZSTD(3)`. ClickHouse fills in default codec parameters,
+// so a codec without parameters matches the same codec with any parameters.
+func SameCodec(configured, actual string) bool {
+	configuredCodecs := splitTopLevel(configured)
+	actualCodecs := splitTopLevel(actual)
+	if len(configuredCodecs) != len(actualCodecs) {
+		return false
+	}
+
+	for i := range configuredCodecs {
+		configuredName, configuredArgs, _ := strings.Cut(configuredCodecs[i], "(")
+		actualName, actualArgs, _ := strings.Cut(actualCodecs[i], "(")
+		if !strings.EqualFold(strings.TrimSpace(configuredName), strings.TrimSpace(actualName)) {
+			return false
+		}
+		if configuredArgs != "" && !SameExpression(configuredArgs, actualArgs) {
+			return false
+		}
+	}
+
+	return true
+}
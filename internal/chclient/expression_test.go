@@ -0,0 +1,55 @@
+package chclient
+
+import (
+	"testing"
+)
+
+func TestSameExpression(t *testing.T) {
+	testCases := []struct {
+		configured string
+		actual     string
+		expected   bool
+	}{
+		{"a = 1", "a = 1", true},
+		{"a=1", "a = 1", true},
+		{"ts + INTERVAL 1 DAY", "ts + toIntervalDay(1)", true},
+		{"ts + interval 3 months", "ts + toIntervalMonth(3)", true},
+		{"ts + INTERVAL 1 DAY", "ts + toIntervalDay(2)", false},
+		{"a = 1", "a = 2", false},
+		{"toStartOfHour(`ts`)", "toStartOfHour(ts)", true},
+		{"cityHash64(`user id`)", "cityHash64(`user id`)", true},
+		{"cityHash64(user_id)", "cityHash64(`user id`)", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.configured, func(t *testing.T) {
+			if actual := SameExpression(tc.configured, tc.actual); actual != tc.expected {
+				t.Errorf("expected %v for %q and %q", tc.expected, tc.configured, tc.actual)
+			}
+		})
+	}
+}
+
+func TestSameCodec(t *testing.T) {
+	testCases := []struct {
+		configured string
+		actual     string
+		expected   bool
+	}{
+		{"", "", true},
+		{"ZSTD(3)", "ZSTD(3)", true},
+		{"Delta, ZSTD(3)", "Delta(4), ZSTD(3)", true},
+		{"delta,zstd", "Delta(4), ZSTD(1)", true},
+		{"Delta(8), ZSTD(3)", "Delta(4), ZSTD(3)", false},
+		{"ZSTD(3)", "Delta(4), ZSTD(3)", false},
+		{"LZ4", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.configured, func(t *testing.T) {
+			if actual := SameCodec(tc.configured, tc.actual); actual != tc.expected {
+				t.Errorf("expected %v for %q and %q", tc.expected, tc.configured, tc.actual)
+			}
+		})
+	}
+}
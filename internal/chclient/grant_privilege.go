@@ -41,7 +41,8 @@ func (client *ClickHouseClient) GrantPrivilege(ctx context.Context, grant Privil
 	}
 
 	query := fmt.Sprintf(
-		"GRANT %s ON %s.%s TO %s",
+		"GRANT%s %s ON %s.%s TO %s",
+		client.onCluster(),
 		what,
 		db,
 		table,
@@ -82,7 +83,8 @@ func (client *ClickHouseClient) RevokePrivilege(ctx context.Context, grant Privi
 	}
 
 	query := fmt.Sprintf(
-		"REVOKE %s ON %s.%s FROM %s",
+		"REVOKE%s %s ON %s.%s FROM %s",
+		client.onCluster(),
 		what,
 		db,
 		table,
@@ -152,5 +154,21 @@ GROUP BY
 		return nil, &NotFoundError{Entity: "privilege grant", Name: name, Query: query}
 	}
 
+	err = client.checkOnAllReplicas(
+		ctx,
+		"privilege grant",
+		fmt.Sprintf("(grantee=%s, access_type=%s)", grantee, accessType),
+		"grants",
+		fmt.Sprintf(
+			`("role_name" = %s OR "user_name" = %s) AND "access_type" = %s AND "is_partial_revoke" = 0`,
+			QuoteValue(grantee),
+			QuoteValue(grantee),
+			QuoteValue(accessType),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return grants, nil
 }
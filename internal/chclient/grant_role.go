@@ -21,7 +21,7 @@ type RoleGrant struct {
 }
 
 func (client *ClickHouseClient) GrantRole(ctx context.Context, roleName, grantee string, withAdminOption bool) error {
-	query := fmt.Sprintf("GRANT %s TO %s", QuoteID(roleName), QuoteID(grantee))
+	query := fmt.Sprintf("GRANT%s %s TO %s", client.onCluster(), QuoteID(roleName), QuoteID(grantee))
 	if withAdminOption {
 		query = query + " WITH ADMIN OPTION"
 	}
@@ -67,6 +67,23 @@ WHERE "user_name" = %s OR "role_name" = %s`,
 	}
 
 	roleGrant.Role = roleName
+
+	err = client.checkOnAllReplicas(
+		ctx,
+		"role grant",
+		roleName,
+		"role_grants",
+		fmt.Sprintf(
+			`"granted_role_name" = %s AND ("user_name" = %s OR "role_name" = %s)`,
+			QuoteValue(roleName),
+			QuoteValue(grantee),
+			QuoteValue(grantee),
+		),
+	)
+	if err != nil {
+		return roleGrant, err
+	}
+
 	return roleGrant, nil
 }
 
@@ -77,7 +94,8 @@ func (client *ClickHouseClient) RevokeRole(ctx context.Context, roleName, grante
 	}
 
 	query := fmt.Sprintf(
-		"REVOKE %s FROM %s",
+		"REVOKE%s %s FROM %s",
+		client.onCluster(),
 		QuoteWithTicks(grant.Role),
 		QuoteWithTicks(grant.Grantee),
 	)
@@ -4,9 +4,21 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/contributors"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
 )
 
+// HttpConn implements driver.Conn for HTTP protocol on top of database/sql,
+// because clickhouse-go supports HTTP for database/sql interface only.
 type HttpConn struct {
 	DB *sql.DB
 }
@@ -15,17 +27,42 @@ type HttpRows struct {
 	*sql.Rows
 }
 
+// HttpRow is the first row of a query result. Unlike *sql.Row, it can be scanned into a struct.
+type HttpRow struct {
+	rows *sql.Rows
+	err  error
+}
+
+// HttpBatch buffers rows in memory and inserts them in a single transaction on Send.
+type HttpBatch struct {
+	ctx     context.Context
+	db      *sql.DB
+	query   string
+	rows    [][]any
+	columns [][]any
+	sent    bool
+}
+
+type httpBatchColumn struct {
+	batch *HttpBatch
+	index int
+}
+
+type httpColumnType struct {
+	*sql.ColumnType
+}
+
 func (c *HttpConn) Ping(ctx context.Context) error {
-	return c.DB.Ping()
+	return c.DB.PingContext(ctx)
 }
 
 func (c *HttpConn) Exec(ctx context.Context, query string, args ...any) error {
-	_, err := c.DB.ExecContext(ctx, query, args)
+	_, err := c.DB.ExecContext(ctx, query, args...)
 	return err
 }
 
 func (c *HttpConn) Query(ctx context.Context, query string, args ...any) (driver.Rows, error) {
-	rows, err := c.DB.QueryContext(ctx, query, args)
+	rows, err := c.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -33,32 +70,112 @@ func (c *HttpConn) Query(ctx context.Context, query string, args ...any) (driver
 	return &HttpRows{rows}, nil
 }
 
-func (c *HttpConn) Contributors() []string {
-	return []string{}
-}
-
-func (c *HttpConn) ServerVersion() (*driver.ServerVersion, error) {
-	return nil, errors.New("not implemented")
+func (c *HttpConn) QueryRow(ctx context.Context, query string, args ...any) driver.Row {
+	rows, err := c.DB.QueryContext(ctx, query, args...)
+	return &HttpRow{rows: rows, err: err}
 }
 
+// Select scans every row of the query result into a struct and appends it to dest, which should be a pointer to a slice.
+// Columns are matched with fields by `ch` tags or by field names.
 func (c *HttpConn) Select(ctx context.Context, dest any, query string, args ...any) error {
-	return errors.New("not implemented")
-}
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("destination must be a pointer to a slice, got %T", dest)
+	}
+	slice := value.Elem()
+	elemType := slice.Type().Elem()
 
-func (c *HttpConn) QueryRow(ctx context.Context, query string, args ...any) driver.Row {
+	rows, err := c.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		elem := reflect.New(elemType)
+		target := elem.Interface()
+		if elemType.Kind() == reflect.Pointer {
+			elem.Elem().Set(reflect.New(elemType.Elem()))
+			target = elem.Elem().Interface()
+		}
+		if err := scanStruct(columns, rows.Scan, target); err != nil {
+			return err
+		}
+		slice = reflect.Append(slice, elem.Elem())
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	value.Elem().Set(slice)
 	return nil
 }
 
 func (c *HttpConn) PrepareBatch(ctx context.Context, query string, opts ...driver.PrepareBatchOption) (driver.Batch, error) {
-	return nil, errors.New("not implemented")
+	return &HttpBatch{ctx: ctx, db: c.DB, query: query}, nil
 }
 
 func (c *HttpConn) AsyncInsert(ctx context.Context, query string, wait bool, args ...any) error {
-	return errors.New("not implemented")
+	_, err := c.DB.ExecContext(clickhouse.Context(ctx, clickhouse.WithStdAsync(wait)), query, args...)
+	return err
+}
+
+func (c *HttpConn) Contributors() []string {
+	list := contributors.List
+	if len(list) > 0 && list[len(list)-1] == "" {
+		return list[:len(list)-1]
+	}
+	return list
+}
+
+// ServerVersion queries the server, because there is no handshake in HTTP protocol.
+func (c *HttpConn) ServerVersion() (*driver.ServerVersion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var version, timezone, hostname string
+	var revision uint32
+	err := c.DB.QueryRowContext(ctx, "SELECT version(), revision(), timezone(), hostName()").
+		Scan(&version, &revision, &timezone, &hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(version, ".")
+	numbers := make([]uint64, 3)
+	for i := 0; i < len(numbers) && i < len(parts); i++ {
+		numbers[i], err = strconv.ParseUint(parts[i], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server version %q: %w", version, err)
+		}
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server timezone %q: %w", timezone, err)
+	}
+
+	return &driver.ServerVersion{
+		Name:        "ClickHouse",
+		DisplayName: hostname,
+		Revision:    uint64(revision),
+		Version:     proto.Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]},
+		Timezone:    location,
+	}, nil
 }
 
 func (c *HttpConn) Stats() driver.Stats {
-	return driver.Stats{}
+	stats := c.DB.Stats()
+	return driver.Stats{
+		MaxOpenConns: stats.MaxOpenConnections,
+		Open:         stats.OpenConnections,
+		Idle:         stats.Idle,
+	}
 }
 
 func (c *HttpConn) Close() error {
@@ -66,22 +183,234 @@ func (c *HttpConn) Close() error {
 }
 
 func (r *HttpRows) ScanStruct(dest any) error {
-	return errors.New("not implemented")
+	columns, err := r.Rows.Columns()
+	if err != nil {
+		return err
+	}
+	return scanStruct(columns, r.Rows.Scan, dest)
 }
 
+// Totals are not available, because database/sql interface of clickhouse-go does not expose them.
 func (r *HttpRows) Totals(dest ...any) error {
-	return errors.New("not implemented")
+	return errors.New("totals are not supported by HTTP protocol")
 }
 
 func (r *HttpRows) ColumnTypes() []driver.ColumnType {
-	return nil
+	types, err := r.Rows.ColumnTypes()
+	if err != nil {
+		return nil
+	}
+
+	result := make([]driver.ColumnType, 0, len(types))
+	for _, t := range types {
+		result = append(result, httpColumnType{t})
+	}
+	return result
 }
 
 func (r *HttpRows) Columns() []string {
-	cols, err := r.Rows.Columns()
+	columns, err := r.Rows.Columns()
+	if err != nil {
+		return nil
+	}
+
+	return columns
+}
+
+func (r *HttpRow) Err() error {
+	return r.err
+}
+
+func (r *HttpRow) Scan(dest ...any) error {
+	return r.scan(func(rows *sql.Rows) error {
+		return rows.Scan(dest...)
+	})
+}
+
+func (r *HttpRow) ScanStruct(dest any) error {
+	return r.scan(func(rows *sql.Rows) error {
+		columns, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		return scanStruct(columns, rows.Scan, dest)
+	})
+}
+
+func (r *HttpRow) scan(scan func(rows *sql.Rows) error) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer func() { _ = r.rows.Close() }()
+
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	return scan(r.rows)
+}
+
+func (t httpColumnType) Nullable() bool {
+	nullable, _ := t.ColumnType.Nullable()
+	return nullable
+}
+
+func (b *HttpBatch) Abort() error {
+	if b.sent {
+		return clickhouse.ErrBatchAlreadySent
+	}
+	b.sent = true
+	b.rows, b.columns = nil, nil
+	return nil
+}
+
+func (b *HttpBatch) Append(v ...any) error {
+	if b.sent {
+		return clickhouse.ErrBatchAlreadySent
+	}
+	b.rows = append(b.rows, v)
+	return nil
+}
+
+// AppendStruct appends values of struct fields in the order of their declaration.
+func (b *HttpBatch) AppendStruct(v any) error {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a struct, got %T", v)
+	}
+
+	var values []any
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() || field.Tag.Get("ch") == "-" {
+			continue
+		}
+		values = append(values, value.Field(i).Interface())
+	}
+	return b.Append(values...)
+}
+
+func (b *HttpBatch) Column(index int) driver.BatchColumn {
+	return &httpBatchColumn{batch: b, index: index}
+}
+
+// Flush does nothing, because rows are inserted on Send.
+func (b *HttpBatch) Flush() error {
+	return nil
+}
+
+func (b *HttpBatch) Send() error {
+	if b.sent {
+		return clickhouse.ErrBatchAlreadySent
+	}
+	b.sent = true
+
+	rows := b.rows
+	if len(b.columns) > 0 {
+		columnRows := len(b.columns[0])
+		for i, values := range b.columns {
+			if len(values) != columnRows {
+				return fmt.Errorf("column %d has %d rows, expected %d", i, len(values), columnRows)
+			}
+		}
+		for i := 0; i < columnRows; i++ {
+			row := make([]any, 0, len(b.columns))
+			for _, values := range b.columns {
+				row = append(row, values[i])
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	tx, err := b.db.BeginTx(b.ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(b.ctx, b.query)
 	if err != nil {
-		panic(err)
+		_ = tx.Rollback()
+		return err
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, row := range rows {
+		if _, err := stmt.ExecContext(b.ctx, row...); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
 	}
+	return tx.Commit()
+}
 
-	return cols
+func (b *HttpBatch) IsSent() bool {
+	return b.sent
 }
+
+func (b *HttpBatch) Rows() int {
+	rows := len(b.rows)
+	if len(b.columns) > 0 {
+		rows += len(b.columns[0])
+	}
+	return rows
+}
+
+// Columns returns nil, because column types are not known before the batch is sent.
+func (b *HttpBatch) Columns() []column.Interface {
+	return nil
+}
+
+func (c *httpBatchColumn) Append(v any) error {
+	if c.batch.sent {
+		return clickhouse.ErrBatchAlreadySent
+	}
+	for len(c.batch.columns) <= c.index {
+		c.batch.columns = append(c.batch.columns, nil)
+	}
+	c.batch.columns[c.index] = append(c.batch.columns[c.index], v)
+	return nil
+}
+
+func (c *httpBatchColumn) AppendRow(v any) error {
+	return c.Append(v)
+}
+
+// scanStruct scans a row into fields of the struct dest points to.
+// Columns are matched with fields by `ch` tags or by field names.
+func scanStruct(columns []string, scan func(dest ...any) error, dest any) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("destination must be a pointer to a struct, got %T", dest)
+	}
+	value = value.Elem()
+
+	fields := make(map[string]int, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := field.Tag.Get("ch")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = i
+	}
+
+	targets := make([]any, 0, len(columns))
+	for _, name := range columns {
+		i, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("missing destination field for column %q in %s", name, value.Type())
+		}
+		targets = append(targets, value.Field(i).Addr().Interface())
+	}
+	return scan(targets...)
+}
+
+var _ driver.Conn = &HttpConn{}
+var _ driver.Rows = &HttpRows{}
+var _ driver.Row = &HttpRow{}
+var _ driver.Batch = &HttpBatch{}
+var _ driver.ColumnType = httpColumnType{}
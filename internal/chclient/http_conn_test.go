@@ -0,0 +1,160 @@
+package chclient
+
+import (
+	"context"
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+// fakeDB is a database/sql driver returning the same result for every query and recording executed statements.
+type fakeDB struct {
+	columns []string
+	rows    [][]sqldriver.Value
+	execs   [][]sqldriver.Value
+	commits int
+}
+
+type fakeConn struct{ db *fakeDB }
+type fakeStmt struct{ db *fakeDB }
+type fakeRows struct {
+	db   *fakeDB
+	next int
+}
+
+func (d *fakeDB) Connect(context.Context) (sqldriver.Conn, error) { return &fakeConn{db: d}, nil }
+func (d *fakeDB) Driver() sqldriver.Driver                        { return nil }
+
+func (c *fakeConn) Prepare(string) (sqldriver.Stmt, error) { return &fakeStmt{db: c.db}, nil }
+func (c *fakeConn) Close() error                           { return nil }
+func (c *fakeConn) Begin() (sqldriver.Tx, error)           { return c, nil }
+func (c *fakeConn) Commit() error                          { c.db.commits++; return nil }
+func (c *fakeConn) Rollback() error                        { return nil }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec(args []sqldriver.Value) (sqldriver.Result, error) {
+	s.db.execs = append(s.db.execs, args)
+	return sqldriver.RowsAffected(1), nil
+}
+func (s *fakeStmt) Query([]sqldriver.Value) (sqldriver.Rows, error) {
+	return &fakeRows{db: s.db}, nil
+}
+
+func (r *fakeRows) Columns() []string { return r.db.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []sqldriver.Value) error {
+	if r.next >= len(r.db.rows) {
+		return io.EOF
+	}
+	copy(dest, r.db.rows[r.next])
+	r.next++
+	return nil
+}
+
+func TestHttpConnSelect(t *testing.T) {
+	type user struct {
+		Name    string `ch:"name"`
+		Storage string `ch:"storage"`
+		Ignored string `ch:"-"`
+	}
+
+	db := &fakeDB{
+		columns: []string{"name", "storage"},
+		rows:    [][]sqldriver.Value{{"alice", "local_directory"}, {"bob", "ldap"}},
+	}
+	conn := &HttpConn{DB: sql.OpenDB(db)}
+	ctx := context.Background()
+
+	var users []user
+	if err := conn.Select(ctx, &users, `SELECT "name", "storage" FROM "system"."users"`); err != nil {
+		t.Fatal(err)
+	}
+	expected := []user{{Name: "alice", Storage: "local_directory"}, {Name: "bob", Storage: "ldap"}}
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("expected %+v, got %+v", expected, users)
+	}
+
+	var first user
+	if err := conn.QueryRow(ctx, `SELECT "name", "storage" FROM "system"."users"`).ScanStruct(&first); err != nil {
+		t.Fatal(err)
+	}
+	if first != expected[0] {
+		t.Errorf("expected %+v, got %+v", expected[0], first)
+	}
+
+	var missing struct {
+		Name string `ch:"name"`
+	}
+	if err := conn.QueryRow(ctx, `SELECT "name", "storage" FROM "system"."users"`).ScanStruct(&missing); err == nil {
+		t.Error("expected error for a column without destination field")
+	}
+
+	db.rows = nil
+	var name string
+	if err := conn.QueryRow(ctx, `SELECT "name" FROM "system"."users"`).Scan(&name); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected %v, got %v", sql.ErrNoRows, err)
+	}
+}
+
+func TestHttpBatch(t *testing.T) {
+	db := &fakeDB{}
+	conn := &HttpConn{DB: sql.OpenDB(db)}
+
+	batch, err := conn.PrepareBatch(context.Background(), `INSERT INTO "db"."t"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Append(int64(1), "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.AppendStruct(struct {
+		ID   int64
+		Name string
+	}{ID: 2, Name: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if batch.Rows() != 2 {
+		t.Errorf("expected 2 rows, got %d", batch.Rows())
+	}
+	if err := batch.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]sqldriver.Value{{int64(1), "a"}, {int64(2), "b"}}
+	if !reflect.DeepEqual(db.execs, expected) || db.commits != 1 {
+		t.Errorf("expected %v in one transaction, got %v in %d", expected, db.execs, db.commits)
+	}
+	if err := batch.Append(int64(3), "c"); !errors.Is(err, clickhouse.ErrBatchAlreadySent) {
+		t.Errorf("expected %v, got %v", clickhouse.ErrBatchAlreadySent, err)
+	}
+}
+
+func TestHttpBatchColumns(t *testing.T) {
+	db := &fakeDB{}
+	conn := &HttpConn{DB: sql.OpenDB(db)}
+
+	batch, err := conn.PrepareBatch(context.Background(), `INSERT INTO "db"."t"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []int64{1, 2} {
+		if err := batch.Column(0).Append(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := batch.Column(1).Append("a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Send(); err == nil {
+		t.Error("expected error for columns of different length")
+	}
+	if len(db.execs) != 0 {
+		t.Errorf("expected no inserted rows, got %v", db.execs)
+	}
+}
@@ -0,0 +1,165 @@
+package chclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type ClickHouseMaterializedView struct {
+	Database string
+	Name     string
+	Query    string
+
+	// ToDatabase and ToTable define the target table (`TO db.table` clause).
+	// If they are empty, the view stores data in an inner table created with Engine.
+	ToDatabase string
+	ToTable    string
+
+	Engine       string
+	EngineParams []string
+	PartitionBy  string
+	OrderBy      []string
+	Populate     bool
+}
+
+const createQueryIdentifierRegexp = "(`(?:[^`\\\\]|\\\\.)+`|[a-zA-Z0-9_]+)"
+
+var materializedViewToRegexp = regexp.MustCompile(
+	"^CREATE MATERIALIZED VIEW " + createQueryIdentifierRegexp + "\\." + createQueryIdentifierRegexp +
+		" TO " + createQueryIdentifierRegexp + "\\." + createQueryIdentifierRegexp,
+)
+
+func (client *ClickHouseClient) CreateMaterializedView(ctx context.Context, view ClickHouseMaterializedView) error {
+	query := fmt.Sprintf(
+		"CREATE MATERIALIZED VIEW %s.%s%s",
+		QuoteID(view.Database),
+		QuoteID(view.Name),
+		client.onCluster(),
+	)
+
+	if view.ToTable != "" {
+		if view.Populate {
+			return &NotSupportedError{
+				Operation: "POPULATE",
+				Detail:    "materialized view with TO clause cannot be populated",
+			}
+		}
+		query += fmt.Sprintf(" TO %s.%s", QuoteID(view.ToDatabase), QuoteID(view.ToTable))
+	} else {
+		if view.Engine == "" {
+			return errors.New("either target table or engine of materialized view should be set")
+		}
+		query += fmt.Sprintf(
+			" ENGINE = %s(%s)",
+			QuoteID(view.Engine),
+			FormatEngineParams(view.EngineParams),
+		)
+
+		if view.PartitionBy != "" {
+			query += " PARTITION BY " + view.PartitionBy
+		}
+
+		if len(view.OrderBy) > 0 {
+			query += " ORDER BY (" + quoteKeyExpressions(view.OrderBy) + ")"
+		}
+
+		if view.Populate {
+			query += " POPULATE"
+		}
+	}
+
+	query += " AS " + view.Query
+
+	tflog.Info(ctx, "Creating a materialized view", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
+
+// ModifyMaterializedViewQuery changes SELECT query of materialized view in place.
+func (client *ClickHouseClient) ModifyMaterializedViewQuery(ctx context.Context, database, name, query string) error {
+	alterQuery := fmt.Sprintf(
+		"ALTER TABLE %s.%s%s MODIFY QUERY %s",
+		QuoteID(database),
+		QuoteID(name),
+		client.onCluster(),
+		query,
+	)
+
+	tflog.Info(ctx, "Modifying query of a materialized view", dict{"query": alterQuery})
+
+	// The setting is required by older ClickHouse versions and ignored by newer ones.
+	ctx = clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{
+		"allow_experimental_alter_materialized_view_structure": 1,
+	}))
+
+	return client.Conn.Exec(ctx, alterQuery)
+}
+
+func (client *ClickHouseClient) GetMaterializedView(ctx context.Context, database, name string) (ClickHouseMaterializedView, error) {
+	info, err := client.GetTable(ctx, database, name)
+	if err != nil {
+		return ClickHouseMaterializedView{}, err
+	}
+
+	if info.Engine != "MaterializedView" {
+		return ClickHouseMaterializedView{}, &NotFoundError{
+			Entity: "materialized view",
+			Name:   fmt.Sprintf("%s.%s (found %s instead)", database, name, info.Engine),
+			Query:  info.CreateTableQuery,
+		}
+	}
+
+	view := ClickHouseMaterializedView{
+		Database: info.Database,
+		Name:     info.Name,
+		Query:    info.AsSelect,
+	}
+
+	matches := materializedViewToRegexp.FindStringSubmatch(info.CreateTableQuery)
+	if len(matches) == 5 {
+		view.ToDatabase = unquoteTicks(matches[3])
+		view.ToTable = unquoteTicks(matches[4])
+		return view, nil
+	}
+
+	inner, err := client.getMaterializedViewInnerTable(ctx, info)
+	if err != nil {
+		return ClickHouseMaterializedView{}, err
+	}
+
+	view.Engine = inner.Engine
+	view.EngineParams = inner.EngineParams
+	view.PartitionBy = inner.PartitionBy
+	view.OrderBy = inner.OrderBy
+
+	return view, nil
+}
+
+// getMaterializedViewInnerTable looks for the table where materialized view without TO clause stores data.
+// It is named `.inner_id.<uuid>` in Atomic databases and `.inner.<name>` in Ordinary ones.
+func (client *ClickHouseClient) getMaterializedViewInnerTable(ctx context.Context, view ClickHouseTableFullInfo) (ClickHouseTableFullInfo, error) {
+	inner, err := client.GetTable(ctx, view.Database, ".inner_id."+view.UUID.String())
+	var notFoundError *NotFoundError
+	if errors.As(err, &notFoundError) {
+		inner, err = client.GetTable(ctx, view.Database, ".inner."+view.Name)
+	}
+
+	return inner, err
+}
+
+func unquoteTicks(name string) string {
+	if len(name) < 2 || !strings.HasPrefix(name, "`") || !strings.HasSuffix(name, "`") {
+		return name
+	}
+
+	name = name[1 : len(name)-1]
+	name = strings.ReplaceAll(name, "\\`", "`")
+	name = strings.ReplaceAll(name, `\\`, `\`)
+	return name
+}
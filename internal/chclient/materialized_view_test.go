@@ -0,0 +1,78 @@
+package chclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestMaterializedViewToRegexp(t *testing.T) {
+	testCases := []struct {
+		name       string
+		query      string
+		toDatabase string
+		toTable    string
+	}{
+		{
+			name:       "Plain identifiers",
+			query:      "CREATE MATERIALIZED VIEW default.mv TO default.dst (`id` UInt64) AS SELECT id FROM default.src",
+			toDatabase: "default",
+			toTable:    "dst",
+		},
+		{
+			name:       "Quoted identifiers",
+			query:      "CREATE MATERIALIZED VIEW `my db`.`my mv` TO `my db`.`my\\`dst` (`id` UInt64) AS SELECT 1",
+			toDatabase: "my db",
+			toTable:    "my`dst",
+		},
+		{
+			name:  "Inner table",
+			query: "CREATE MATERIALIZED VIEW default.mv (`id` UInt64) ENGINE = MergeTree ORDER BY id AS SELECT id FROM default.src",
+		},
+		{
+			name:  "TO inside query",
+			query: "CREATE MATERIALIZED VIEW default.mv (`id` UInt64) ENGINE = Memory AS SELECT 'a TO b.c' AS id",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var toDatabase, toTable string
+			matches := materializedViewToRegexp.FindStringSubmatch(tc.query)
+			if len(matches) == 5 {
+				toDatabase, toTable = unquoteTicks(matches[3]), unquoteTicks(matches[4])
+			}
+
+			if toDatabase != tc.toDatabase || toTable != tc.toTable {
+				t.Errorf("expected %q.%q, got %q.%q", tc.toDatabase, tc.toTable, toDatabase, toTable)
+			}
+		})
+	}
+}
+
+func TestCreateMaterializedViewOrderBy(t *testing.T) {
+	view := ClickHouseMaterializedView{
+		Database: "db",
+		Name:     "mv",
+		Engine:   "MergeTree",
+		OrderBy:  []string{"user_id", "toDate(ts)"},
+		Query:    "SELECT user_id, ts FROM db.src",
+	}
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	conn := mock_driver.NewMockConn(mockCtrl)
+	conn.EXPECT().Exec(
+		ctx,
+		`CREATE MATERIALIZED VIEW "db"."mv" ENGINE = "MergeTree"() ORDER BY (`+"`user_id`"+`, toDate(ts)) AS SELECT user_id, ts FROM db.src`,
+	).Return(nil).Times(1)
+
+	client := ClickHouseClient{Conn: conn}
+	if err := client.CreateMaterializedView(ctx, view); err != nil {
+		t.Fatal(err)
+	}
+}
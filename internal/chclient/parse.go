@@ -1,42 +1,182 @@
 package chclient
 
 import (
-	"regexp"
 	"strings"
 )
 
-func MustParseSettings(engineFull string) map[string]string {
-	settings := make(map[string]string)
+// topLevelDDLTokens tokenizes s and returns tokens with their nesting levels, or false if s is malformed.
+func topLevelDDLTokens(s string) ([]ddlToken, []int, bool) {
+	tokens, err := tokenizeDDL(s)
+	if err != nil {
+		return nil, nil, false
+	}
+	depths, err := ddlDepths(tokens)
+	if err != nil {
+		return nil, nil, false
+	}
+	return tokens, depths, true
+}
 
-	index := strings.Index(engineFull, "SETTINGS ")
-	if index == -1 {
-		return settings
+// splitTopLevel splits s by commas ignoring commas inside brackets and quotes.
+// Parts are trimmed, empty input gives no parts. Malformed input is returned as a single part.
+func splitTopLevel(s string) []string {
+	tokens, depths, ok := topLevelDDLTokens(s)
+	if !ok {
+		return []string{strings.TrimSpace(s)}
+	}
+	if len(tokens) == 0 {
+		return nil
 	}
 
-	input := strings.TrimSpace(engineFull[index+len("SETTINGS "):])
+	var parts []string
+	for _, part := range splitDDLTokens(tokens, depths, 0) {
+		if len(part) == 0 {
+			parts = append(parts, "")
+			continue
+		}
+		parts = append(parts, rawDDL(s, part))
+	}
+	return parts
+}
+
+// indexTopLevelKeyword returns the index of the first occurrence of the keyword, e.g. `TTL` or `GROUP BY`,
+// as separate words outside brackets and quotes, or -1.
+func indexTopLevelKeyword(s string, keyword string) int {
+	tokens, depths, ok := topLevelDDLTokens(s)
+	if !ok {
+		return -1
+	}
 
-	for _, s := range strings.Split(input, ", ") {
-		parts := strings.Split(s, " = ")
-		if len(parts) != 2 {
-			panic("Invalid settings string: " + engineFull)
+	words := strings.Fields(keyword)
+	for i := 0; i+len(words) <= len(tokens); i++ {
+		if depths[i] != 0 {
+			continue
+		}
+		found := true
+		for j, word := range words {
+			if tokens[i+j].kind != ddlIdent || tokens[i+j].text != word {
+				found = false
+				break
+			}
+		}
+		if found {
+			return tokens[i].start
 		}
-		key := parts[0]
-		value := parts[1]
-		value = strings.TrimLeft(value, "'")
-		value = strings.TrimRight(value, "'")
+	}
+
+	return -1
+}
 
-		settings[key] = value
+// tableElements returns tokens of column, index, projection and constraint definitions from a CREATE TABLE query.
+func tableElements(createQuery string) [][]ddlToken {
+	tokens, depths, ok := topLevelDDLTokens(createQuery)
+	if !ok {
+		return nil
 	}
 
-	return settings
+	start := -1
+	for i, token := range tokens {
+		if start == -1 && token.isPunct("(") {
+			start = i
+			continue
+		}
+		if start != -1 && depths[i] == depths[start] && token.isPunct(")") {
+			var elements [][]ddlToken
+			for _, element := range splitDDLTokens(tokens[start+1:i], depths[start+1:i], depths[start]+1) {
+				if len(element) > 0 {
+					elements = append(elements, element)
+				}
+			}
+			return elements
+		}
+	}
+
+	return nil
 }
 
-func MustParseEngineParams(engineFull string) []string {
-	re := regexp.MustCompile(`\S\((.*?)\)`)
-	matches := re.FindStringSubmatch(engineFull)
-	if len(matches) == 0 {
-		return make([]string, 0)
+// columnDefinitions returns definitions of columns from a CREATE TABLE query mapped by column names.
+// Indexes, projections and constraints are skipped.
+func columnDefinitions(createQuery string) map[string]string {
+	result := make(map[string]string)
+
+	for _, element := range tableElements(createQuery) {
+		name := element[0]
+		switch {
+		case name.kind == ddlIdent && (name.text == "INDEX" || name.text == "PROJECTION" || name.text == "CONSTRAINT"):
+			continue
+		case name.kind != ddlIdent && name.kind != ddlQuotedIdent:
+			continue
+		}
+
+		var definition string
+		if len(element) > 1 {
+			definition = rawDDL(createQuery, element[1:])
+		}
+		result[name.text] = definition
+	}
+
+	return result
+}
+
+// isDDLName reports whether the token is a plain or quoted identifier.
+func isDDLName(token ddlToken) bool {
+	return token.kind == ddlIdent || token.kind == ddlQuotedIdent
+}
+
+// parseProjections returns projections from a CREATE TABLE query.
+func parseProjections(createQuery string) []ClickHouseProjection {
+	var projections []ClickHouseProjection
+
+	for _, element := range tableElements(createQuery) {
+		// PROJECTION name (query)
+		if len(element) < 5 || element[0].kind != ddlIdent || element[0].text != "PROJECTION" || !isDDLName(element[1]) ||
+			!element[2].isPunct("(") || !element[len(element)-1].isPunct(")") {
+			continue
+		}
+		projections = append(projections, ClickHouseProjection{
+			Name:  element[1].text,
+			Query: strings.Join(strings.Fields(rawDDL(createQuery, element[3:len(element)-1])), " "),
+		})
+	}
+
+	return projections
+}
+
+// parseConstraints returns CHECK and ASSUME constraints from a CREATE TABLE query.
+func parseConstraints(createQuery string) []ClickHouseConstraint {
+	var constraints []ClickHouseConstraint
+
+	for _, element := range tableElements(createQuery) {
+		// CONSTRAINT name CHECK|ASSUME expression
+		if len(element) < 4 || element[0].kind != ddlIdent || element[0].text != "CONSTRAINT" || !isDDLName(element[1]) ||
+			element[2].kind != ddlIdent || (element[2].text != "CHECK" && element[2].text != "ASSUME") {
+			continue
+		}
+		constraints = append(constraints, ClickHouseConstraint{
+			Name:       element[1].text,
+			Type:       element[2].text,
+			Expression: rawDDL(createQuery, element[3:]),
+		})
+	}
+
+	return constraints
+}
+
+// parseColumnTTLs returns TTL expressions of columns from a CREATE TABLE query.
+func parseColumnTTLs(createQuery string) map[string]string {
+	ttls := make(map[string]string)
+
+	for name, definition := range columnDefinitions(createQuery) {
+		index := indexTopLevelKeyword(definition, "TTL")
+		if index == -1 {
+			continue
+		}
+		ttl := definition[index+len("TTL"):]
+		if settingsIndex := indexTopLevelKeyword(ttl, "SETTINGS"); settingsIndex != -1 {
+			ttl = ttl[:settingsIndex]
+		}
+		ttls[name] = strings.TrimSpace(ttl)
 	}
 
-	return strings.Split(matches[1], ", ")
+	return ttls
 }
@@ -0,0 +1,116 @@
+package chclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseColumnTTLs(t *testing.T) {
+	createQuery := "CREATE TABLE default.events\n(\n" +
+		"    `ts` DateTime,\n" +
+		"    `payload` String CODEC(ZSTD(3)) TTL ts + toIntervalDay(7),\n" +
+		"    `note` String COMMENT 'TTL is not here',\n" +
+		"    `tags` Array(String) TTL ts + toIntervalMonth(1) SETTINGS (max_compress_block_size = 1000000),\n" +
+		"    INDEX idx payload TYPE bloom_filter GRANULARITY 1\n" +
+		")\nENGINE = MergeTree\nORDER BY ts\nTTL ts + toIntervalYear(1)\nSETTINGS index_granularity = 8192"
+
+	expected := map[string]string{
+		"payload": "ts + toIntervalDay(7)",
+		"tags":    "ts + toIntervalMonth(1)",
+	}
+
+	actual := parseColumnTTLs(createQuery)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestParseProjections(t *testing.T) {
+	createQuery := "CREATE TABLE default.events\n(\n" +
+		"    `user_id` UInt64,\n" +
+		"    `projection` String,\n" +
+		"    INDEX idx user_id TYPE minmax GRANULARITY 1,\n" +
+		"    PROJECTION by_user\n    (\n        SELECT *\n        ORDER BY user_id\n    ),\n" +
+		"    PROJECTION `totals` (SELECT user_id, count() GROUP BY user_id)\n" +
+		")\nENGINE = MergeTree\nORDER BY tuple()\nSETTINGS index_granularity = 8192"
+
+	expected := []ClickHouseProjection{
+		{Name: "by_user", Query: "SELECT * ORDER BY user_id"},
+		{Name: "totals", Query: "SELECT user_id, count() GROUP BY user_id"},
+	}
+
+	actual := parseProjections(createQuery)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestParseConstraints(t *testing.T) {
+	createQuery := "CREATE TABLE default.payments\n(\n" +
+		"    `amount` Decimal(18, 4),\n" +
+		"    `currency` String,\n" +
+		"    CONSTRAINT c_positive CHECK amount >= 0,\n" +
+		"    CONSTRAINT `c_currency` ASSUME currency IN ('USD', 'EUR')\n" +
+		")\nENGINE = MergeTree\nORDER BY tuple()\nSETTINGS index_granularity = 8192"
+
+	expected := []ClickHouseConstraint{
+		{Name: "c_positive", Type: "CHECK", Expression: "amount >= 0"},
+		{Name: "c_currency", Type: "ASSUME", Expression: "currency IN ('USD', 'EUR')"},
+	}
+
+	actual := parseConstraints(createQuery)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestSplitTopLevel(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected []string
+	}{
+		{s: "", expected: nil},
+		{s: "id, toStartOfHour(ts)", expected: []string{"id", "toStartOfHour(ts)"}},
+		{s: "concat('a,\\'b', `c,d`), [1, 2]", expected: []string{"concat('a,\\'b', `c,d`)", "[1, 2]"}},
+		{s: "a,", expected: []string{"a", ""}},
+	}
+
+	for _, tc := range testCases {
+		if actual := splitTopLevel(tc.s); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected %q, got %q", tc.s, tc.expected, actual)
+		}
+	}
+}
+
+func TestIndexTopLevelKeyword(t *testing.T) {
+	testCases := []struct {
+		s        string
+		keyword  string
+		expected int
+	}{
+		{s: "ts + toIntervalDay(1) GROUP  BY id", keyword: "GROUP BY", expected: 22},
+		{s: "x = 'GROUP BY' AND y", keyword: "GROUP BY", expected: -1},
+		{s: "f(a TTL b) TTL c", keyword: "TTL", expected: 11},
+		{s: "TTL_column", keyword: "TTL", expected: -1},
+	}
+
+	for _, tc := range testCases {
+		if actual := indexTopLevelKeyword(tc.s, tc.keyword); actual != tc.expected {
+			t.Errorf("%s: expected %d, got %d", tc.s, tc.expected, actual)
+		}
+	}
+}
+
+func FuzzParseCreateTableQuery(f *testing.F) {
+	f.Add("CREATE TABLE t (`a` String TTL ts + 1, PROJECTION p (SELECT *), CONSTRAINT c CHECK a != 'x)') ENGINE = Memory")
+	f.Add("CREATE TABLE t (`a\\` String, CONSTRAINT c ASSUME")
+	f.Add("CREATE TABLE t (a String)) TTL")
+
+	f.Fuzz(func(t *testing.T, createQuery string) {
+		// Malformed input must not cause a panic.
+		_ = parseColumnTTLs(createQuery)
+		_ = parseProjections(createQuery)
+		_ = parseConstraints(createQuery)
+		_ = parseTableTTL(createQuery)
+	})
+}
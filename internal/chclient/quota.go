@@ -0,0 +1,293 @@
+package chclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// QuotaKeys are the allowed values of `KEYED BY` clause.
+var QuotaKeys = []string{
+	"user_name",
+	"ip_address",
+	"client_key",
+	"client_key,user_name",
+	"client_key,ip_address",
+}
+
+// quotaIntervalUnits maps interval units to seconds the same way ClickHouse does for quota durations.
+var quotaIntervalUnits = []struct {
+	name    string
+	seconds uint64
+}{
+	{"year", 31556952},
+	{"quarter", 7889238},
+	{"month", 2629746},
+	{"week", 604800},
+	{"day", 86400},
+	{"hour", 3600},
+	{"minute", 60},
+	{"second", 1},
+}
+
+// ParseQuotaDuration converts intervals like `1 hour` or `30 minutes` to seconds.
+func ParseQuotaDuration(duration string) (uint32, error) {
+	fields := strings.Fields(strings.ToLower(duration))
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("quota duration should be in `<number> <unit>` format, got %q", duration)
+	}
+
+	number, err := strconv.ParseUint(fields[0], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number in quota duration %q: %w", duration, err)
+	}
+
+	unit := strings.TrimSuffix(fields[1], "s")
+	for _, u := range quotaIntervalUnits {
+		if u.name == unit {
+			seconds := number * u.seconds
+			if seconds > uint64(^uint32(0)) {
+				return 0, fmt.Errorf("quota duration %q is too long", duration)
+			}
+			return uint32(seconds), nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown unit in quota duration %q", duration)
+}
+
+// FormatQuotaDuration converts seconds to an interval with the largest unit that divides it.
+func FormatQuotaDuration(seconds uint32) string {
+	for _, u := range quotaIntervalUnits {
+		if uint64(seconds)%u.seconds == 0 && uint64(seconds) >= u.seconds {
+			return fmt.Sprintf("%d %s", uint64(seconds)/u.seconds, u.name)
+		}
+	}
+	return fmt.Sprintf("%d second", seconds)
+}
+
+// ClickHouseQuotaLimits is a single `FOR INTERVAL` element of a quota. Nil limits are not set.
+type ClickHouseQuotaLimits struct {
+	Duration         uint32
+	Randomized       bool
+	MaxQueries       *uint64
+	MaxQuerySelects  *uint64
+	MaxQueryInserts  *uint64
+	MaxErrors        *uint64
+	MaxResultRows    *uint64
+	MaxResultBytes   *uint64
+	MaxReadRows      *uint64
+	MaxReadBytes     *uint64
+	MaxExecutionTime *float64
+}
+
+func (limits ClickHouseQuotaLimits) String() string {
+	randomized := ""
+	if limits.Randomized {
+		randomized = " RANDOMIZED"
+	}
+	result := fmt.Sprintf("FOR%s INTERVAL %d second", randomized, limits.Duration)
+
+	maxValues := make([]string, 0)
+	for _, limit := range []struct {
+		name  string
+		value *uint64
+	}{
+		{"queries", limits.MaxQueries},
+		{"query_selects", limits.MaxQuerySelects},
+		{"query_inserts", limits.MaxQueryInserts},
+		{"errors", limits.MaxErrors},
+		{"result_rows", limits.MaxResultRows},
+		{"result_bytes", limits.MaxResultBytes},
+		{"read_rows", limits.MaxReadRows},
+		{"read_bytes", limits.MaxReadBytes},
+	} {
+		if limit.value != nil {
+			maxValues = append(maxValues, fmt.Sprintf("%s = %d", limit.name, *limit.value))
+		}
+	}
+	if limits.MaxExecutionTime != nil {
+		maxValues = append(
+			maxValues,
+			"execution_time = "+strconv.FormatFloat(*limits.MaxExecutionTime, 'f', -1, 64),
+		)
+	}
+
+	if len(maxValues) == 0 {
+		return result + " TRACKING ONLY"
+	}
+
+	return result + " MAX " + strings.Join(maxValues, ", ")
+}
+
+type ClickHouseQuota struct {
+	Name    string
+	KeyedBy string
+	Limits  []ClickHouseQuotaLimits
+	ApplyTo *ClickHouseApplyTo
+}
+
+func (quota ClickHouseQuota) keyedByQuery() string {
+	if quota.KeyedBy == "" {
+		return "NOT KEYED"
+	}
+	return "KEYED BY " + quota.KeyedBy
+}
+
+func (client *ClickHouseClient) CreateQuota(ctx context.Context, quota ClickHouseQuota) error {
+	query := fmt.Sprintf(
+		"CREATE QUOTA %s%s %s",
+		QuoteID(quota.Name),
+		client.onCluster(),
+		quota.keyedByQuery(),
+	)
+
+	limits := make([]string, 0, len(quota.Limits))
+	for _, l := range quota.Limits {
+		limits = append(limits, l.String())
+	}
+	if len(limits) > 0 {
+		query += " " + strings.Join(limits, ", ")
+	}
+
+	query += " TO " + quota.ApplyTo.String()
+
+	tflog.Info(ctx, "Creating a quota", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
+
+// AlterQuota changes the quota from orig to quota.
+// ALTER QUOTA merges intervals with existing ones, so all intervals of orig are dropped first.
+func (client *ClickHouseClient) AlterQuota(ctx context.Context, orig ClickHouseQuota, quota ClickHouseQuota) error {
+	renameQuery := ""
+	if orig.Name != quota.Name {
+		renameQuery = " RENAME TO " + QuoteID(quota.Name)
+	}
+
+	query := fmt.Sprintf(
+		"ALTER QUOTA %s%s%s %s",
+		QuoteID(orig.Name),
+		client.onCluster(),
+		renameQuery,
+		quota.keyedByQuery(),
+	)
+
+	limits := make([]string, 0, len(orig.Limits)+len(quota.Limits))
+	for _, l := range orig.Limits {
+		limits = append(limits, fmt.Sprintf("FOR INTERVAL %d second NO LIMITS", l.Duration))
+	}
+	for _, l := range quota.Limits {
+		limits = append(limits, l.String())
+	}
+	if len(limits) > 0 {
+		query += " " + strings.Join(limits, ", ")
+	}
+
+	query += " TO " + quota.ApplyTo.String()
+
+	tflog.Info(ctx, "Altering a quota", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
+
+func (client *ClickHouseClient) DropQuota(ctx context.Context, name string) error {
+	query := fmt.Sprintf("DROP QUOTA %s%s", QuoteID(name), client.onCluster())
+
+	tflog.Info(ctx, "Dropping a quota", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
+
+func (client *ClickHouseClient) GetQuota(ctx context.Context, name string) (ClickHouseQuota, error) {
+	query := fmt.Sprintf(
+		`SELECT "name", arrayMap(k -> toString(k), "keys"), "apply_to_all", "apply_to_list", "apply_to_except"
+FROM "system"."quotas"
+WHERE "name" = %s`,
+		QuoteValue(name),
+	)
+
+	tflog.Info(ctx, "Looking for a quota", dict{"query": query})
+
+	rows, err := client.Conn.Query(ctx, query)
+	if err != nil {
+		return ClickHouseQuota{}, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		return ClickHouseQuota{}, &NotFoundError{Entity: "quota", Name: name, Query: query}
+	}
+
+	var quota ClickHouseQuota
+	var keys []string
+	var applyToAll uint8
+	var applyToList, applyToExcept []string
+	err = rows.Scan(&quota.Name, &keys, &applyToAll, &applyToList, &applyToExcept)
+	if err != nil {
+		return ClickHouseQuota{}, err
+	}
+	quota.ApplyTo = newClickHouseApplyTo(applyToAll == 1, applyToList, applyToExcept)
+
+	quota.KeyedBy = strings.Join(keys, ",")
+
+	limitsQuery := fmt.Sprintf(
+		`SELECT
+    "duration",
+    "is_randomized_interval",
+    "max_queries",
+    "max_query_selects",
+    "max_query_inserts",
+    "max_errors",
+    "max_result_rows",
+    "max_result_bytes",
+    "max_read_rows",
+    "max_read_bytes",
+    "max_execution_time"
+FROM "system"."quota_limits"
+WHERE "quota_name" = %s
+ORDER BY "duration"`,
+		QuoteValue(name),
+	)
+
+	tflog.Info(ctx, "Looking for quota limits", dict{"query": limitsQuery})
+
+	limitsRows, err := client.Conn.Query(ctx, limitsQuery)
+	if err != nil {
+		return ClickHouseQuota{}, err
+	}
+	defer func() { _ = limitsRows.Close() }()
+
+	for limitsRows.Next() {
+		var limits ClickHouseQuotaLimits
+		var randomized uint8
+		err = limitsRows.Scan(
+			&limits.Duration,
+			&randomized,
+			&limits.MaxQueries,
+			&limits.MaxQuerySelects,
+			&limits.MaxQueryInserts,
+			&limits.MaxErrors,
+			&limits.MaxResultRows,
+			&limits.MaxResultBytes,
+			&limits.MaxReadRows,
+			&limits.MaxReadBytes,
+			&limits.MaxExecutionTime,
+		)
+		if err != nil {
+			return ClickHouseQuota{}, err
+		}
+		limits.Randomized = randomized == 1
+		quota.Limits = append(quota.Limits, limits)
+	}
+
+	err = client.checkOnAllReplicas(ctx, "quota", name, "quotas", `"name" = `+QuoteValue(name))
+	if err != nil {
+		return ClickHouseQuota{}, err
+	}
+
+	return quota, nil
+}
@@ -0,0 +1,95 @@
+package chclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestQuotaDuration(t *testing.T) {
+	testCases := []struct {
+		duration  string
+		seconds   uint32
+		formatted string
+	}{
+		{duration: "1 hour", seconds: 3600, formatted: "1 hour"},
+		{duration: "90 minutes", seconds: 5400, formatted: "90 minute"},
+		{duration: "2 DAYS", seconds: 172800, formatted: "2 day"},
+		{duration: "1 week", seconds: 604800, formatted: "1 week"},
+		{duration: "1 month", seconds: 2629746, formatted: "1 month"},
+		{duration: "61 seconds", seconds: 61, formatted: "61 second"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.duration, func(t *testing.T) {
+			seconds, err := ParseQuotaDuration(tc.duration)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if seconds != tc.seconds {
+				t.Errorf("expected %d seconds, got %d", tc.seconds, seconds)
+			}
+			if formatted := FormatQuotaDuration(seconds); formatted != tc.formatted {
+				t.Errorf("expected %q, got %q", tc.formatted, formatted)
+			}
+		})
+	}
+
+	for _, duration := range []string{"", "hour", "1", "1 fortnight", "-1 hour", "1000000 years"} {
+		if _, err := ParseQuotaDuration(duration); err == nil {
+			t.Errorf("expected error for %q", duration)
+		}
+	}
+}
+
+func TestQuotaSQL(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	queries := uint64(100)
+	readBytes := uint64(1000000)
+	executionTime := 1.5
+
+	conn := mock_driver.NewMockConn(mockCtrl)
+	conn.EXPECT().Exec(
+		ctx,
+		`CREATE QUOTA "q" KEYED BY client_key,user_name `+
+			`FOR INTERVAL 3600 second MAX queries = 100, read_bytes = 1000000, execution_time = 1.5, `+
+			`FOR RANDOMIZED INTERVAL 86400 second TRACKING ONLY `+
+			`TO "alice"`,
+	).Return(nil).Times(1)
+	conn.EXPECT().Exec(
+		ctx,
+		`ALTER QUOTA "q" RENAME TO "q2" NOT KEYED `+
+			`FOR INTERVAL 3600 second NO LIMITS, FOR INTERVAL 86400 second NO LIMITS, `+
+			`FOR INTERVAL 3600 second MAX queries = 100 `+
+			`TO ALL`,
+	).Return(nil).Times(1)
+
+	client := &ClickHouseClient{Conn: conn}
+	quota := ClickHouseQuota{
+		Name:    "q",
+		KeyedBy: "client_key,user_name",
+		Limits: []ClickHouseQuotaLimits{
+			{Duration: 3600, MaxQueries: &queries, MaxReadBytes: &readBytes, MaxExecutionTime: &executionTime},
+			{Duration: 86400, Randomized: true},
+		},
+		ApplyTo: &ClickHouseApplyTo{Names: []string{"alice"}},
+	}
+
+	if err := client.CreateQuota(ctx, quota); err != nil {
+		t.Fatal(err)
+	}
+
+	err := client.AlterQuota(ctx, quota, ClickHouseQuota{
+		Name:    "q2",
+		Limits:  []ClickHouseQuotaLimits{{Duration: 3600, MaxQueries: &queries}},
+		ApplyTo: &ClickHouseApplyTo{All: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
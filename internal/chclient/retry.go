@@ -0,0 +1,279 @@
+package chclient
+
+import (
+	"context"
+	sqldriver "database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultRetryableCodes are codes of ClickHouse exceptions which are usually caused by a temporary state of the server.
+var DefaultRetryableCodes = []int32{
+	202, // TOO_MANY_SIMULTANEOUS_QUERIES
+	209, // SOCKET_TIMEOUT
+	210, // NETWORK_ERROR
+	225, // NO_ZOOKEEPER
+	242, // TABLE_IS_READ_ONLY
+	473, // DEADLOCK_AVOIDED
+	517, // CANNOT_ASSIGN_ALTER
+	999, // KEEPER_EXCEPTION
+}
+
+// RetryPolicy describes how queries failed due to transient errors are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts to run a query including the first one. Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. It is doubled after every attempt up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RetryableCodes are codes of ClickHouse exceptions to retry. Network errors are always retried if it is safe.
+	RetryableCodes []int32
+}
+
+// DefaultRetryPolicy returns the policy used by the provider unless it is configured otherwise.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+		RetryableCodes: slices.Clone(DefaultRetryableCodes),
+	}
+}
+
+// backoff returns the delay before the given retry with equal jitter.
+func (policy RetryPolicy) backoff(retry int) time.Duration {
+	delay := policy.InitialBackoff
+	for i := 1; i < retry && delay < policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+		delay = policy.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// retryable reports whether err is transient. If it is, applied tells whether the failed attempt
+// might have been executed by the server, e.g. when the connection is lost while waiting for the result.
+func (policy RetryPolicy) retryable(err error) (retryable bool, applied bool) {
+	var exception *ExceptionError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, clickhouse.ErrAcquireConnTimeout):
+		return true, false
+	case errors.As(err, &exception):
+		if !slices.Contains(policy.RetryableCodes, exception.Code) {
+			return false, false
+		}
+		var keeperError *KeeperError
+		var timeoutError *TimeoutError
+		return true, errors.As(err, &keeperError) || errors.As(err, &timeoutError)
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE),
+		errors.Is(err, sqldriver.ErrBadConn), errors.As(err, &netErr):
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// statement describes whether a query can be run again if the outcome of the previous attempt is unknown.
+type statement struct {
+	idempotent bool
+	// create is set for CREATE queries which fail if the object exists. existsQuery counts objects created by them.
+	create      bool
+	existsQuery string
+	// drop is set for DROP queries which fail if the object does not exist.
+	drop bool
+}
+
+func parseStatement(query string) statement {
+	tokens, err := tokenizeDDL(query)
+	if err != nil || len(tokens) == 0 {
+		return statement{idempotent: query == ""}
+	}
+
+	var words []string
+	for _, token := range tokens {
+		if token.kind != ddlIdent {
+			break
+		}
+		words = append(words, strings.ToUpper(token.text))
+	}
+	has := func(phrase string) bool {
+		return strings.Contains(" "+strings.Join(words, " ")+" ", " "+phrase+" ")
+	}
+
+	if tokens[0].kind != ddlIdent {
+		return statement{}
+	}
+
+	switch strings.ToUpper(tokens[0].text) {
+	case "SELECT", "WITH", "SHOW", "DESCRIBE", "DESC", "EXISTS", "EXPLAIN", "GRANT", "REVOKE":
+		return statement{idempotent: true}
+	case "CREATE":
+		if has("OR REPLACE") || has("IF NOT EXISTS") {
+			return statement{idempotent: true}
+		}
+		return statement{create: true, existsQuery: existsQuery(words, tokens[len(words):])}
+	case "DROP":
+		return statement{idempotent: has("IF EXISTS"), drop: !has("IF EXISTS")}
+	default:
+		return statement{}
+	}
+}
+
+// existsQuery builds a query counting the object created by CREATE query.
+// words are the leading keywords of the query, and tokens follow them starting with the name of the object.
+func existsQuery(words []string, tokens []ddlToken) string {
+	// The client always quotes names, unquoted ones are consumed together with keywords.
+	if len(tokens) == 0 || tokens[0].kind != ddlQuotedIdent || len(words) < 2 {
+		return ""
+	}
+	// Names of tables, views and dictionaries may be qualified with a database.
+	database, name := "", tokens[0].text
+	if len(tokens) > 2 && tokens[1].isPunct(".") && tokens[2].kind == ddlQuotedIdent {
+		database, name = name, tokens[2].text
+	}
+
+	switch kind := strings.Join(words[1:], " "); kind {
+	case "DATABASE":
+		return fmt.Sprintf(`SELECT count() FROM "system"."databases" WHERE "name" = %s`, QuoteValue(name))
+	case "TABLE", "VIEW", "MATERIALIZED VIEW", "DICTIONARY":
+		databaseCondition := "currentDatabase()"
+		if database != "" {
+			databaseCondition = QuoteValue(database)
+		}
+		return fmt.Sprintf(
+			`SELECT count() FROM "system"."tables" WHERE "database" = %s AND "name" = %s`,
+			databaseCondition,
+			QuoteValue(name),
+		)
+	case "USER", "ROLE", "QUOTA", "SETTINGS PROFILE":
+		if database != "" {
+			return ""
+		}
+		table := map[string]string{
+			"USER":             "users",
+			"ROLE":             "roles",
+			"QUOTA":            "quotas",
+			"SETTINGS PROFILE": "settings_profiles",
+		}[kind]
+		return fmt.Sprintf(`SELECT count() FROM "system".%s WHERE "name" = %s`, QuoteID(table), QuoteValue(name))
+	default:
+		return ""
+	}
+}
+
+// retryConn retries queries failed due to transient errors according to the policy.
+// Queries which might have been executed by a failed attempt are retried only if it is safe:
+// CREATE is not repeated if the object exists, and DROP succeeds if the object was dropped by a failed attempt.
+type retryConn struct {
+	driver.Conn
+	policy RetryPolicy
+}
+
+func (c *retryConn) Ping(ctx context.Context) error {
+	return c.retry(ctx, "", func() error {
+		return c.Conn.Ping(ctx)
+	})
+}
+
+func (c *retryConn) Exec(ctx context.Context, query string, args ...any) error {
+	return c.retry(ctx, query, func() error {
+		return c.Conn.Exec(ctx, query, args...)
+	})
+}
+
+func (c *retryConn) Query(ctx context.Context, query string, args ...any) (driver.Rows, error) {
+	var rows driver.Rows
+	err := c.retry(ctx, query, func() error {
+		var err error
+		rows, err = c.Conn.Query(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (c *retryConn) Select(ctx context.Context, dest any, query string, args ...any) error {
+	return c.retry(ctx, query, func() error {
+		return c.Conn.Select(ctx, dest, query, args...)
+	})
+}
+
+func (c *retryConn) retry(ctx context.Context, query string, run func() error) error {
+	stmt := parseStatement(query)
+	mightBeApplied := false
+
+	for attempt := 1; ; attempt++ {
+		err := run()
+		if err != nil && stmt.drop && mightBeApplied && IsNotFound(err) {
+			tflog.Info(ctx, "Object was dropped by a failed attempt", dict{"query": query})
+			return nil
+		}
+		if err == nil || attempt >= c.policy.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		retryable, applied := c.policy.retryable(err)
+		if !retryable || applied && !stmt.idempotent && !stmt.drop && stmt.existsQuery == "" {
+			return err
+		}
+		mightBeApplied = mightBeApplied || applied
+
+		delay := c.policy.backoff(attempt)
+		tflog.Warn(ctx, "Retrying a query after a transient error", dict{
+			"query":   query,
+			"error":   err.Error(),
+			"attempt": attempt,
+			"delay":   delay.String(),
+		})
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		if stmt.create && mightBeApplied {
+			exists, existsErr := c.exists(ctx, stmt.existsQuery)
+			if existsErr != nil {
+				return errors.Join(err, existsErr)
+			}
+			if exists {
+				tflog.Info(ctx, "Object was created by a failed attempt", dict{"query": query})
+				return nil
+			}
+		}
+	}
+}
+
+func (c *retryConn) exists(ctx context.Context, query string) (bool, error) {
+	tflog.Info(ctx, "Checking if the object exists before retrying", dict{"query": query})
+
+	rows, err := c.Conn.Query(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var count uint64
+	if rows.Next() {
+		if err = rows.Scan(&count); err != nil {
+			return false, err
+		}
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,187 @@
+package chclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestParseStatement(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected statement
+	}{
+		{query: `SELECT "name" FROM "system"."tables"`, expected: statement{idempotent: true}},
+		{query: `GRANT SELECT ON "db".* TO "user"`, expected: statement{idempotent: true}},
+		{query: `CREATE OR REPLACE VIEW "db"."v" AS SELECT 1`, expected: statement{idempotent: true}},
+		{query: `DROP TABLE IF EXISTS "db"."t"`, expected: statement{idempotent: true}},
+		{query: `DROP TABLE "db"."t" ON CLUSTER "c"`, expected: statement{drop: true}},
+		{query: `ALTER TABLE "db"."t" ADD COLUMN "c" String`, expected: statement{}},
+		{
+			query: `CREATE TABLE "db"."t" ("id" UInt64) ENGINE = "MergeTree"() ORDER BY ("id")`,
+			expected: statement{
+				create:      true,
+				existsQuery: `SELECT count() FROM "system"."tables" WHERE "database" = 'db' AND "name" = 't'`,
+			},
+		},
+		{
+			query: `CREATE MATERIALIZED VIEW "db"."mv" TO "db"."t" AS SELECT 1`,
+			expected: statement{
+				create:      true,
+				existsQuery: `SELECT count() FROM "system"."tables" WHERE "database" = 'db' AND "name" = 'mv'`,
+			},
+		},
+		{
+			query: `CREATE DATABASE "db" ON CLUSTER "c" ENGINE = Atomic`,
+			expected: statement{
+				create:      true,
+				existsQuery: `SELECT count() FROM "system"."databases" WHERE "name" = 'db'`,
+			},
+		},
+		{
+			query: `CREATE SETTINGS PROFILE "profile" SETTINGS max_threads = 1 TO NONE`,
+			expected: statement{
+				create:      true,
+				existsQuery: `SELECT count() FROM "system"."settings_profiles" WHERE "name" = 'profile'`,
+			},
+		},
+		{query: `CREATE ROW POLICY "p" ON "db"."t" USING 1 TO ALL`, expected: statement{create: true}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			if actual := parseStatement(tc.query); actual != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRetryConn(t *testing.T) {
+	tooManyQueries := classifyError(&clickhouse.Exception{Code: 202, Message: "Too many simultaneous queries"})
+	syntaxError := classifyError(&clickhouse.Exception{Code: 62, Message: "Syntax error"})
+	unknownTable := classifyError(&clickhouse.Exception{Code: 60, Message: "Table db.t does not exist"})
+	connectionLost := io.ErrUnexpectedEOF
+
+	createTable := `CREATE TABLE "db"."t" ("id" UInt64) ENGINE = "MergeTree"() ORDER BY ("id")`
+	tableExists := `SELECT count() FROM "system"."tables" WHERE "database" = 'db' AND "name" = 't'`
+	alterTable := `ALTER TABLE "db"."t" ADD COLUMN "c" String`
+	dropTable := `DROP TABLE "db"."t"`
+
+	type exec struct {
+		query string
+		err   error
+		// exists is the result of the query when it is run with Query.
+		exists *uint64
+	}
+	zero, one := uint64(0), uint64(1)
+
+	testCases := []struct {
+		name     string
+		query    string
+		execs    []exec
+		expected error
+	}{
+		{
+			name:  "Rejected query is retried",
+			query: alterTable,
+			execs: []exec{{query: alterTable, err: tooManyQueries}, {query: alterTable}},
+		},
+		{
+			name:     "Attempts are limited",
+			query:    alterTable,
+			execs:    []exec{{query: alterTable, err: tooManyQueries}, {query: alterTable, err: tooManyQueries}, {query: alterTable, err: tooManyQueries}},
+			expected: tooManyQueries,
+		},
+		{
+			name:     "Other errors are not retried",
+			query:    alterTable,
+			execs:    []exec{{query: alterTable, err: syntaxError}},
+			expected: syntaxError,
+		},
+		{
+			name:     "ALTER is not retried if it might have been applied",
+			query:    alterTable,
+			execs:    []exec{{query: alterTable, err: connectionLost}},
+			expected: connectionLost,
+		},
+		{
+			name:  "CREATE is not repeated if the object was created",
+			query: createTable,
+			execs: []exec{{query: createTable, err: connectionLost}, {query: tableExists, exists: &one}},
+		},
+		{
+			name:  "CREATE is repeated if the object was not created",
+			query: createTable,
+			execs: []exec{{query: createTable, err: connectionLost}, {query: tableExists, exists: &zero}, {query: createTable}},
+		},
+		{
+			name:  "DROP succeeds if the object was dropped",
+			query: dropTable,
+			execs: []exec{{query: dropTable, err: connectionLost}, {query: dropTable, err: unknownTable}},
+		},
+		{
+			name:     "DROP of a missing object fails",
+			query:    dropTable,
+			execs:    []exec{{query: dropTable, err: unknownTable}},
+			expected: unknownTable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			conn := mock_driver.NewMockConn(mockCtrl)
+			var calls []*gomock.Call
+			for _, e := range tc.execs {
+				if e.exists == nil {
+					calls = append(calls, conn.EXPECT().Exec(ctx, e.query).Return(e.err).Times(1))
+					continue
+				}
+
+				rows := mock_driver.NewMockRows(mockCtrl)
+				count := *e.exists
+				rows.EXPECT().Next().Return(true).Times(1)
+				rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+					result, ok := dest[0].(*uint64)
+					if !ok {
+						return errors.New("unexpected scan destination")
+					}
+					*result = count
+					return nil
+				}).Times(1)
+				rows.EXPECT().Close().Return(nil).Times(1)
+				calls = append(calls, conn.EXPECT().Query(ctx, e.query).Return(rows, nil).Times(1))
+			}
+			gomock.InOrder(calls...)
+
+			policy := RetryPolicy{MaxAttempts: 3, RetryableCodes: DefaultRetryableCodes}
+			client := ClickHouseClient{Conn: &retryConn{Conn: conn, policy: policy}}
+
+			err := client.Conn.Exec(ctx, tc.query)
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	for retry, expected := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		actual := policy.backoff(retry)
+		if actual < expected/2 || actual > expected {
+			t.Errorf("retry %d: expected backoff between %s and %s, got %s", retry, expected/2, expected, actual)
+		}
+	}
+}
@@ -6,7 +6,7 @@ import (
 )
 
 func (client *ClickHouseClient) CreateRole(ctx context.Context, name string) error {
-	query := fmt.Sprintf("CREATE ROLE %s", QuoteID(name))
+	query := fmt.Sprintf("CREATE ROLE %s%s", QuoteID(name), client.onCluster())
 	return client.Conn.Exec(ctx, query)
 }
 
@@ -27,15 +27,20 @@ func (client *ClickHouseClient) GetRole(ctx context.Context, roleName string) (s
 		return "", err
 	}
 
+	err = client.checkOnAllReplicas(ctx, "role", roleName, "roles", `"name" = `+QuoteValue(roleName))
+	if err != nil {
+		return "", err
+	}
+
 	return receivedName, nil
 }
 
 func (client *ClickHouseClient) RenameRole(ctx context.Context, from, to string) error {
-	query := fmt.Sprintf("ALTER ROLE %s RENAME TO %s", QuoteID(from), QuoteID(to))
+	query := fmt.Sprintf("ALTER ROLE %s RENAME TO %s%s", QuoteID(from), QuoteID(to), client.onCluster())
 	return client.Conn.Exec(ctx, query)
 }
 
 func (client *ClickHouseClient) DropRole(ctx context.Context, roleName string) error {
-	query := fmt.Sprintf("DROP ROLE %s", QuoteID(roleName))
+	query := fmt.Sprintf("DROP ROLE %s%s", QuoteID(roleName), client.onCluster())
 	return client.Conn.Exec(ctx, query)
 }
@@ -0,0 +1,159 @@
+package chclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type ClickHouseRowPolicy struct {
+	Name     string
+	Database string
+	// Table is `*` for policies applied to all tables of the database.
+	Table       string
+	Using       string
+	Restrictive bool
+	ApplyTo     *ClickHouseApplyTo
+}
+
+// FullName returns the name ClickHouse uses for the policy: `name ON database.table`.
+func (policy ClickHouseRowPolicy) FullName() string {
+	return fmt.Sprintf("%s ON %s.%s", policy.Name, policy.Database, policy.Table)
+}
+
+func rowPolicyTarget(database, table string) string {
+	if table == "*" {
+		return QuoteID(database) + ".*"
+	}
+	return QuoteID(database) + "." + QuoteID(table)
+}
+
+func (policy ClickHouseRowPolicy) kindQuery() string {
+	if policy.Restrictive {
+		return "AS RESTRICTIVE"
+	}
+	return "AS PERMISSIVE"
+}
+
+func (client *ClickHouseClient) CreateRowPolicy(ctx context.Context, policy ClickHouseRowPolicy) error {
+	query := fmt.Sprintf(
+		"CREATE ROW POLICY %s%s ON %s FOR SELECT USING %s %s TO %s",
+		QuoteID(policy.Name),
+		client.onCluster(),
+		rowPolicyTarget(policy.Database, policy.Table),
+		policy.Using,
+		policy.kindQuery(),
+		policy.ApplyTo.String(),
+	)
+
+	tflog.Info(ctx, "Creating a row policy", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
+
+func (client *ClickHouseClient) AlterRowPolicy(ctx context.Context, origName string, policy ClickHouseRowPolicy) error {
+	renameQuery := ""
+	if origName != policy.Name {
+		renameQuery = " RENAME TO " + QuoteID(policy.Name)
+	}
+
+	query := fmt.Sprintf(
+		"ALTER ROW POLICY %s%s ON %s%s FOR SELECT USING %s %s TO %s",
+		QuoteID(origName),
+		client.onCluster(),
+		rowPolicyTarget(policy.Database, policy.Table),
+		renameQuery,
+		policy.Using,
+		policy.kindQuery(),
+		policy.ApplyTo.String(),
+	)
+
+	tflog.Info(ctx, "Altering a row policy", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
+
+func (client *ClickHouseClient) DropRowPolicy(ctx context.Context, name, database, table string) error {
+	query := fmt.Sprintf(
+		"DROP ROW POLICY %s ON %s%s",
+		QuoteID(name),
+		rowPolicyTarget(database, table),
+		client.onCluster(),
+	)
+
+	tflog.Info(ctx, "Dropping a row policy", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
+
+func (client *ClickHouseClient) GetRowPolicy(ctx context.Context, name, database, table string) (ClickHouseRowPolicy, error) {
+	// Policies for all tables of a database have empty table name in system.row_policies.
+	tableCondition := table
+	if table == "*" {
+		tableCondition = ""
+	}
+	condition := fmt.Sprintf(
+		`"short_name" = %s AND "database" = %s AND "table" = %s`,
+		QuoteValue(name),
+		QuoteValue(database),
+		QuoteValue(tableCondition),
+	)
+
+	query := fmt.Sprintf(
+		`SELECT
+    "short_name",
+    "database",
+    "table",
+    ifNull("select_filter", ''),
+    "is_restrictive",
+    "apply_to_all",
+    "apply_to_list",
+    "apply_to_except"
+FROM "system"."row_policies"
+WHERE %s`,
+		condition,
+	)
+
+	tflog.Info(ctx, "Looking for a row policy", dict{"query": query})
+
+	rows, err := client.Conn.Query(ctx, query)
+	if err != nil {
+		return ClickHouseRowPolicy{}, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	fullName := fmt.Sprintf("%s ON %s.%s", name, database, table)
+	if !rows.Next() {
+		return ClickHouseRowPolicy{}, &NotFoundError{Entity: "row policy", Name: fullName, Query: query}
+	}
+
+	var policy ClickHouseRowPolicy
+	var restrictive, applyToAll uint8
+	var applyToList, applyToExcept []string
+	err = rows.Scan(
+		&policy.Name,
+		&policy.Database,
+		&policy.Table,
+		&policy.Using,
+		&restrictive,
+		&applyToAll,
+		&applyToList,
+		&applyToExcept,
+	)
+	if err != nil {
+		return ClickHouseRowPolicy{}, err
+	}
+	if policy.Table == "" {
+		policy.Table = "*"
+	}
+	policy.Restrictive = restrictive == 1
+	policy.ApplyTo = newClickHouseApplyTo(applyToAll == 1, applyToList, applyToExcept)
+
+	err = client.checkOnAllReplicas(ctx, "row policy", fullName, "row_policies", condition)
+	if err != nil {
+		return ClickHouseRowPolicy{}, err
+	}
+
+	return policy, nil
+}
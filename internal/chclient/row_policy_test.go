@@ -0,0 +1,58 @@
+package chclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestRowPolicySQL(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	conn := mock_driver.NewMockConn(mockCtrl)
+	conn.EXPECT().Exec(
+		ctx,
+		`CREATE ROW POLICY "tenant" ON CLUSTER "c" ON "db"."events" FOR SELECT USING tenant_id = 1 AS RESTRICTIVE TO ALL EXCEPT "admin"`,
+	).Return(nil).Times(1)
+	conn.EXPECT().Exec(
+		ctx,
+		`ALTER ROW POLICY "tenant" ON "db".* RENAME TO "tenant2" FOR SELECT USING 1 AS PERMISSIVE TO "alice"`,
+	).Return(nil).Times(1)
+	conn.EXPECT().Exec(
+		ctx,
+		`DROP ROW POLICY "tenant2" ON "db".*`,
+	).Return(nil).Times(1)
+
+	client := &ClickHouseClient{Conn: conn}
+
+	err := client.WithCluster("c").CreateRowPolicy(ctx, ClickHouseRowPolicy{
+		Name:        "tenant",
+		Database:    "db",
+		Table:       "events",
+		Using:       "tenant_id = 1",
+		Restrictive: true,
+		ApplyTo:     &ClickHouseApplyTo{All: true, Except: []string{"admin"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.AlterRowPolicy(ctx, "tenant", ClickHouseRowPolicy{
+		Name:     "tenant2",
+		Database: "db",
+		Table:    "*",
+		Using:    "1",
+		ApplyTo:  &ClickHouseApplyTo{Names: []string{"alice"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = client.DropRowPolicy(ctx, "tenant2", "db", "*"); err != nil {
+		t.Fatal(err)
+	}
+}
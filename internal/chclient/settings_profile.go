@@ -0,0 +1,177 @@
+package chclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ClickHouseSettingConstraint is a setting with its value and constraints inside a settings profile.
+// Empty Value, Min, Max and Writability are omitted.
+type ClickHouseSettingConstraint struct {
+	Name        string
+	Value       string
+	Min         string
+	Max         string
+	Writability string
+}
+
+func (setting ClickHouseSettingConstraint) String() string {
+	result := QuoteWithTicks(setting.Name)
+
+	if setting.Value != "" {
+		result += " = " + QuoteValue(setting.Value)
+	}
+	if setting.Min != "" {
+		result += " MIN " + QuoteValue(setting.Min)
+	}
+	if setting.Max != "" {
+		result += " MAX " + QuoteValue(setting.Max)
+	}
+	if setting.Writability != "" {
+		result += " " + setting.Writability
+	}
+
+	return result
+}
+
+type ClickHouseSettingsProfile struct {
+	Name     string
+	Inherit  []string
+	Settings []ClickHouseSettingConstraint
+	ApplyTo  *ClickHouseApplyTo
+}
+
+func (profile ClickHouseSettingsProfile) settingsQuery() string {
+	elements := make([]string, 0, len(profile.Inherit)+len(profile.Settings))
+	for _, inherit := range profile.Inherit {
+		elements = append(elements, "INHERIT "+QuoteValue(inherit))
+	}
+	for _, setting := range profile.Settings {
+		elements = append(elements, setting.String())
+	}
+
+	if len(elements) == 0 {
+		return "NONE"
+	}
+
+	return strings.Join(elements, ", ")
+}
+
+func (client *ClickHouseClient) CreateSettingsProfile(ctx context.Context, profile ClickHouseSettingsProfile) error {
+	query := fmt.Sprintf(
+		"CREATE SETTINGS PROFILE %s%s SETTINGS %s TO %s",
+		QuoteID(profile.Name),
+		client.onCluster(),
+		profile.settingsQuery(),
+		profile.ApplyTo.String(),
+	)
+
+	tflog.Info(ctx, "Creating a settings profile", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
+
+func (client *ClickHouseClient) AlterSettingsProfile(ctx context.Context, origName string, profile ClickHouseSettingsProfile) error {
+	renameQuery := ""
+	if origName != profile.Name {
+		renameQuery = " RENAME TO " + QuoteID(profile.Name)
+	}
+
+	query := fmt.Sprintf(
+		"ALTER SETTINGS PROFILE %s%s%s SETTINGS %s TO %s",
+		QuoteID(origName),
+		client.onCluster(),
+		renameQuery,
+		profile.settingsQuery(),
+		profile.ApplyTo.String(),
+	)
+
+	tflog.Info(ctx, "Altering a settings profile", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
+
+func (client *ClickHouseClient) DropSettingsProfile(ctx context.Context, name string) error {
+	query := fmt.Sprintf("DROP SETTINGS PROFILE %s%s", QuoteID(name), client.onCluster())
+
+	tflog.Info(ctx, "Dropping a settings profile", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
+
+func (client *ClickHouseClient) GetSettingsProfile(ctx context.Context, name string) (ClickHouseSettingsProfile, error) {
+	query := fmt.Sprintf(
+		`SELECT "name", "apply_to_all", "apply_to_list", "apply_to_except"
+FROM "system"."settings_profiles"
+WHERE "name" = %s`,
+		QuoteValue(name),
+	)
+
+	tflog.Info(ctx, "Looking for a settings profile", dict{"query": query})
+
+	rows, err := client.Conn.Query(ctx, query)
+	if err != nil {
+		return ClickHouseSettingsProfile{}, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		return ClickHouseSettingsProfile{}, &NotFoundError{Entity: "settings profile", Name: name, Query: query}
+	}
+
+	var profile ClickHouseSettingsProfile
+	var applyToAll uint8
+	var applyToList, applyToExcept []string
+	err = rows.Scan(&profile.Name, &applyToAll, &applyToList, &applyToExcept)
+	if err != nil {
+		return ClickHouseSettingsProfile{}, err
+	}
+	profile.ApplyTo = newClickHouseApplyTo(applyToAll == 1, applyToList, applyToExcept)
+
+	elementsQuery := fmt.Sprintf(
+		`SELECT
+    ifNull("setting_name", ''),
+    ifNull("value", ''),
+    ifNull("min", ''),
+    ifNull("max", ''),
+    ifNull(toString("writability"), ''),
+    ifNull("inherit_profile", '')
+FROM "system"."settings_profile_elements"
+WHERE "profile_name" = %s
+ORDER BY "index"`,
+		QuoteValue(name),
+	)
+
+	tflog.Info(ctx, "Looking for settings profile elements", dict{"query": elementsQuery})
+
+	elements, err := client.Conn.Query(ctx, elementsQuery)
+	if err != nil {
+		return ClickHouseSettingsProfile{}, err
+	}
+	defer func() { _ = elements.Close() }()
+
+	for elements.Next() {
+		var setting ClickHouseSettingConstraint
+		var inherit string
+		err = elements.Scan(&setting.Name, &setting.Value, &setting.Min, &setting.Max, &setting.Writability, &inherit)
+		if err != nil {
+			return ClickHouseSettingsProfile{}, err
+		}
+
+		if inherit != "" {
+			profile.Inherit = append(profile.Inherit, inherit)
+		} else {
+			profile.Settings = append(profile.Settings, setting)
+		}
+	}
+
+	err = client.checkOnAllReplicas(ctx, "settings profile", name, "settings_profiles", `"name" = `+QuoteValue(name))
+	if err != nil {
+		return ClickHouseSettingsProfile{}, err
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,60 @@
+package chclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestSettingsProfileSQL(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	conn := mock_driver.NewMockConn(mockCtrl)
+	conn.EXPECT().Exec(
+		ctx,
+		`CREATE SETTINGS PROFILE "limited" SETTINGS INHERIT 'readonly', `+
+			"`max_memory_usage` = '10000000000' MIN '1000000' MAX '20000000000' WRITABLE, "+
+			"`max_threads` CONST "+
+			`TO ALL EXCEPT "admin"`,
+	).Return(nil).Times(1)
+	conn.EXPECT().Exec(
+		ctx,
+		`ALTER SETTINGS PROFILE "limited" RENAME TO "restricted" SETTINGS NONE TO "alice", "analysts"`,
+	).Return(nil).Times(1)
+	conn.EXPECT().Exec(
+		ctx,
+		`ALTER SETTINGS PROFILE "restricted" SETTINGS NONE TO NONE`,
+	).Return(nil).Times(1)
+
+	client := &ClickHouseClient{Conn: conn}
+
+	err := client.CreateSettingsProfile(ctx, ClickHouseSettingsProfile{
+		Name:    "limited",
+		Inherit: []string{"readonly"},
+		Settings: []ClickHouseSettingConstraint{
+			{Name: "max_memory_usage", Value: "10000000000", Min: "1000000", Max: "20000000000", Writability: "WRITABLE"},
+			{Name: "max_threads", Writability: "CONST"},
+		},
+		ApplyTo: &ClickHouseApplyTo{All: true, Except: []string{"admin"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.AlterSettingsProfile(ctx, "limited", ClickHouseSettingsProfile{
+		Name:    "restricted",
+		ApplyTo: &ClickHouseApplyTo{Names: []string{"alice", "analysts"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.AlterSettingsProfile(ctx, "restricted", ClickHouseSettingsProfile{Name: "restricted"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
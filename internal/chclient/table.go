@@ -3,10 +3,14 @@ package chclient
 import (
 	"context"
 	"fmt"
+	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/emirpasic/gods/v2/sets/hashset"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"reflect"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 )
@@ -16,6 +20,14 @@ type ClickHouseColumn struct {
 	Type     string
 	Comment  string
 	Nullable bool
+	// DefaultKind is one of DEFAULT, MATERIALIZED, ALIAS and EPHEMERAL, or empty for ordinary columns.
+	DefaultKind       string
+	DefaultExpression string
+	// Codec lists compression codecs without the CODEC keyword, e.g. `Delta, ZSTD(3)`.
+	Codec string
+	TTL   string
+	// PreviousName makes AlterTable rename the column with this name instead of adding a new one.
+	PreviousName string
 }
 
 type ClickHouseColumns []ClickHouseColumn
@@ -37,8 +49,21 @@ type ClickHouseTable struct {
 	PartitionBy   string
 	OrderBy       []string
 	PrimaryKeyArr []string
+	SampleBy      string
 	Settings      map[string]string
 	Columns       ClickHouseColumns
+	TTL           []ClickHouseTTLRule
+	Indexes       []ClickHouseIndex
+	Projections   []ClickHouseProjection
+	Constraints   []ClickHouseConstraint
+	// MaterializeTTL makes AlterTable rewrite existing data when TTL rules change.
+	MaterializeTTL bool
+	// MaterializeIndexes makes AlterTable build added indexes for existing data.
+	MaterializeIndexes bool
+	// MaterializeProjections makes AlterTable build added projections for existing data.
+	MaterializeProjections bool
+	// AllowDropColumns lets AlterTable drop columns missing from the table. Otherwise, it returns NotSupportedError.
+	AllowDropColumns bool
 }
 
 type ClickHouseTableFullInfo struct {
@@ -77,7 +102,12 @@ type ClickHouseTableFullInfo struct {
 	PartitionBy   string
 	OrderBy       []string
 	PrimaryKeyArr []string
+	SampleBy      string
 	Settings      map[string]string
+	TTL           []ClickHouseTTLRule
+	Indexes       []ClickHouseIndex
+	Projections   []ClickHouseProjection
+	Constraints   []ClickHouseConstraint
 }
 
 func (info ClickHouseTableFullInfo) ToTable() ClickHouseTable {
@@ -88,26 +118,61 @@ func (info ClickHouseTableFullInfo) ToTable() ClickHouseTable {
 		Engine:       info.Engine,
 		EngineParams: info.EngineParams,
 		OrderBy:      info.OrderBy,
+		SampleBy:     info.SampleBy,
 		Settings:     info.Settings,
 		Columns:      info.Columns,
+		TTL:          info.TTL,
+		Indexes:      info.Indexes,
+		Projections:  info.Projections,
+		Constraints:  info.Constraints,
 	}
 }
 
+// FullType returns the column type wrapped into `Nullable(...)` if the column is nullable.
+func (col ClickHouseColumn) FullType() string {
+	if col.Nullable {
+		return "Nullable(" + col.Type + ")"
+	}
+	return col.Type
+}
+
+// DefaultQuery returns the default expression clause, e.g. `MATERIALIZED toDate(ts)`.
+func (col ClickHouseColumn) DefaultQuery() string {
+	if col.DefaultKind == "" {
+		return ""
+	}
+	if col.DefaultExpression == "" {
+		return col.DefaultKind
+	}
+	return col.DefaultKind + " " + col.DefaultExpression
+}
+
+func (col ClickHouseColumn) typeAndDefault() string {
+	if col.DefaultKind == "" {
+		return col.FullType()
+	}
+	return col.FullType() + " " + col.DefaultQuery()
+}
+
 func (col ClickHouseColumn) String() string {
 	result := fmt.Sprintf(
 		"%s %s",
 		QuoteWithTicks(col.Name),
-		QuoteID(col.Type),
+		col.typeAndDefault(),
 	)
 
-	if col.Nullable {
-		result += " NULL"
-	}
-
 	if col.Comment != "" {
 		result += " COMMENT " + QuoteValue(col.Comment)
 	}
 
+	if col.Codec != "" {
+		result += " CODEC(" + col.Codec + ")"
+	}
+
+	if col.TTL != "" {
+		result += " TTL " + col.TTL
+	}
+
 	return result
 }
 
@@ -116,28 +181,46 @@ func (client *ClickHouseClient) CreateTable(ctx context.Context, table ClickHous
 	for _, col := range table.Columns {
 		columnsStr = append(columnsStr, col.String())
 	}
+	for _, index := range table.Indexes {
+		columnsStr = append(columnsStr, index.String())
+	}
+	for _, projection := range table.Projections {
+		columnsStr = append(columnsStr, projection.String())
+	}
+	for _, constraint := range table.Constraints {
+		columnsStr = append(columnsStr, constraint.String())
+	}
 
 	query := fmt.Sprintf(
-		`CREATE TABLE %s.%s
+		`CREATE TABLE %s.%s%s
 (
 %s
 ) ENGINE = %s(%s)`,
 		QuoteID(table.Database),
 		QuoteID(table.Name),
+		client.onCluster(),
 		strings.Join(columnsStr, ",\n"),
 		QuoteID(table.Engine),
-		strings.Join(QuoteList(table.EngineParams, "`"), " "),
+		FormatEngineParams(table.EngineParams),
 	)
 	if table.PartitionBy != "" {
 		query += " PARTITION BY " + table.PartitionBy + " "
 	}
 
 	if len(table.OrderBy) > 0 {
-		query += " ORDER BY (" + QuoteListWithTicksAndJoin(table.OrderBy) + ")"
+		query += " ORDER BY (" + quoteKeyExpressions(table.OrderBy) + ")"
 	}
 
 	if len(table.PrimaryKeyArr) > 0 {
-		query += " PRIMARY KEY (" + QuoteListWithTicksAndJoin(table.PrimaryKeyArr) + ")"
+		query += " PRIMARY KEY (" + quoteKeyExpressions(table.PrimaryKeyArr) + ")"
+	}
+
+	if table.SampleBy != "" {
+		query += " SAMPLE BY " + table.SampleBy
+	}
+
+	if len(table.TTL) > 0 {
+		query += " TTL " + ttlQuery(table.TTL)
 	}
 
 	if len(table.Settings) > 0 {
@@ -246,35 +329,61 @@ WHERE "database" = %s AND "name" = %s`,
 		return ClickHouseTableFullInfo{}, err
 	}
 
+	err = client.checkOnAllReplicas(
+		ctx,
+		"table",
+		fmt.Sprintf("%s.%s", database, table),
+		"tables",
+		fmt.Sprintf(`"database" = %s AND "name" = %s`, QuoteValue(database), QuoteValue(table)),
+	)
+	if err != nil {
+		return ClickHouseTableFullInfo{}, err
+	}
+
 	tableInfo.PartitionBy = tableInfo.PartitionKey
 
-	if tableInfo.SortingKey != "" {
-		tableInfo.OrderBy = strings.Split(tableInfo.SortingKey, ", ")
-	} else {
-		tableInfo.OrderBy = make([]string, 0)
-	}
+	tableInfo.OrderBy = append(make([]string, 0), splitTopLevel(tableInfo.SortingKey)...)
+	tableInfo.PrimaryKeyArr = append(make([]string, 0), splitTopLevel(tableInfo.PrimaryKey)...)
+	tableInfo.SampleBy = tableInfo.SamplingKey
 
-	if tableInfo.PrimaryKey != "" {
-		tableInfo.PrimaryKeyArr = strings.Split(tableInfo.PrimaryKey, ", ")
-	} else {
-		tableInfo.PrimaryKeyArr = make([]string, 0)
+	tableInfo.Settings, err = ParseSettings(tableInfo.EngineFull)
+	if err != nil {
+		return ClickHouseTableFullInfo{}, err
 	}
-
-	tableInfo.Settings = MustParseSettings(tableInfo.EngineFull)
-	tableInfo.EngineParams = MustParseEngineParams(tableInfo.EngineFull)
+	tableInfo.EngineParams, err = ParseEngineParams(tableInfo.EngineFull)
+	if err != nil {
+		return ClickHouseTableFullInfo{}, err
+	}
+	tableInfo.TTL = parseTableTTL(tableInfo.EngineFull)
 
 	cols, err := client.GetColumns(ctx, database, table)
 	if err != nil {
 		return ClickHouseTableFullInfo{}, err
 	}
+	ttls := parseColumnTTLs(tableInfo.CreateTableQuery)
+	for i := range cols {
+		cols[i].TTL = ttls[cols[i].Name]
+	}
 	tableInfo.Columns = cols
 
+	tableInfo.Indexes, err = client.GetIndexes(ctx, database, table)
+	if err != nil {
+		return ClickHouseTableFullInfo{}, err
+	}
+
+	tableInfo.Projections, err = client.GetProjections(ctx, database, table, tableInfo.CreateTableQuery)
+	if err != nil {
+		return ClickHouseTableFullInfo{}, err
+	}
+
+	tableInfo.Constraints = parseConstraints(tableInfo.CreateTableQuery)
+
 	return tableInfo, nil
 }
 
 func (client *ClickHouseClient) GetColumns(ctx context.Context, database, table string) (ClickHouseColumns, error) {
 	query := fmt.Sprintf(
-		`SELECT "name", "type", "comment" from "system"."columns"
+		`SELECT "name", "type", "comment", "default_kind", "default_expression", "compression_codec" from "system"."columns"
 where database = %s and table = %s
 order by "position"`,
 		QuoteValue(database),
@@ -289,15 +398,16 @@ order by "position"`,
 
 	for rows.Next() {
 		var col ClickHouseColumn
-		err := rows.Scan(&col.Name, &col.Type, &col.Comment)
+		err := rows.Scan(&col.Name, &col.Type, &col.Comment, &col.DefaultKind, &col.DefaultExpression, &col.Codec)
 		if err != nil {
 			return nil, err
 		}
+		col.Codec = strings.TrimSuffix(strings.TrimPrefix(col.Codec, "CODEC("), ")")
 
-		if strings.Contains(col.Type, "Nullable") {
-			col.Nullable = true
-			col.Type = strings.ReplaceAll(col.Type, "Nullable(", "")
-			col.Type = strings.ReplaceAll(col.Type, ")", "")
+		// Types unknown to the parser are kept as ClickHouse reports them.
+		if colType, err := ParseColumnType(col.Type); err == nil {
+			col.Nullable = colType.IsNullable()
+			col.Type = colType.Unwrap().String()
 		}
 
 		cols = append(cols, col)
@@ -318,36 +428,99 @@ func (client *ClickHouseClient) AlterTable(ctx context.Context, currentTableName
 	}
 	currentTable := currentTableInfo.ToTable()
 
-	err = client.AlterColumns(ctx, currentTable, desiredTable)
+	return client.alterTableTo(ctx, currentTable, desiredTable)
+}
+
+// alterTableTo brings the current table to the desired state. Obsolete indexes, projections and constraints
+// are dropped before columns are altered, because they may depend on changed or dropped columns,
+// and new ones are added afterwards, because they may depend on added columns.
+func (client *ClickHouseClient) alterTableTo(ctx context.Context, currentTable, desiredTable ClickHouseTable) error {
+	err := client.dropIndexes(ctx, currentTable, desiredTable)
+	if err != nil {
+		return err
+	}
+
+	err = client.dropProjections(ctx, currentTable, desiredTable)
+	if err != nil {
+		return err
+	}
+
+	err = client.dropConstraints(ctx, currentTable, desiredTable)
+	if err != nil {
+		return err
+	}
+
+	// Settings are changed by the same query as most of column changes.
+	batches, err := columnClauses(currentTable, desiredTable)
+	if err != nil {
+		return err
+	}
+	batches[2] = append(batches[2], settingsClauses(currentTable, desiredTable)...)
+	for _, batch := range batches {
+		err := client.alterTable(ctx, desiredTable.Database, desiredTable.Name, batch)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.addIndexes(ctx, currentTable, desiredTable)
 	if err != nil {
 		return err
 	}
 
-	err = client.AlterTableSettings(ctx, currentTable, desiredTable)
+	err = client.addProjections(ctx, currentTable, desiredTable)
 	if err != nil {
 		return err
 	}
 
-	if len(desiredTable.OrderBy) > 1 {
+	err = client.addConstraints(ctx, currentTable, desiredTable)
+	if err != nil {
+		return err
+	}
+
+	if len(desiredTable.OrderBy) > 0 && !SameExpressions(currentTable.OrderBy, desiredTable.OrderBy) {
 		err = client.ModifyOrderBy(ctx, currentTable.Database, currentTable.Name, desiredTable.OrderBy)
 		if err != nil {
 			return err
 		}
 	}
 
+	if !SameTTL(desiredTable.TTL, currentTable.TTL) {
+		err = client.AlterTableTTL(ctx, currentTable.Database, currentTable.Name, desiredTable.TTL, desiredTable.MaterializeTTL)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+var plainIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// quoteKeyExpressions joins elements of ORDER BY or PRIMARY KEY clauses.
+// Plain column names are quoted, other elements are expressions like `toStartOfHour(ts)` and are kept as is.
+func quoteKeyExpressions(exprs []string) string {
+	result := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		if plainIdentifierRegexp.MatchString(expr) {
+			expr = QuoteWithTicks(expr)
+		}
+		result = append(result, expr)
+	}
+	return strings.Join(result, ", ")
+}
+
 func (client *ClickHouseClient) RenameTable(ctx context.Context, db, from, to string) error {
 	if from == to {
 		return nil
 	}
 	query := fmt.Sprintf(
-		"RENAME TABLE %s.%s TO %s.%s",
+		"RENAME TABLE %s.%s TO %s.%s%s",
 		QuoteID(db),
 		QuoteID(from),
 		QuoteID(db),
 		QuoteID(to),
+		client.onCluster(),
 	)
 	tflog.Info(ctx, "Renaming a table", dict{"query": query})
 
@@ -356,189 +529,236 @@ func (client *ClickHouseClient) RenameTable(ctx context.Context, db, from, to st
 
 func (client *ClickHouseClient) ModifyOrderBy(ctx context.Context, db, table string, orderBy []string) error {
 	query := fmt.Sprintf(
-		"ALTER TABLE %s.%s MODIFY ORDER BY (%s)",
+		"ALTER TABLE %s.%s%s MODIFY ORDER BY (%s)",
 		QuoteID(db),
 		QuoteID(table),
-		QuoteListWithTicksAndJoin(orderBy),
+		client.onCluster(),
+		quoteKeyExpressions(orderBy),
 	)
-	tflog.Info(ctx, "Renaming a table", dict{"query": query})
+	tflog.Info(ctx, "Modifying a table sorting key", dict{"query": query})
 
 	return client.Conn.Exec(ctx, query)
 }
 
-func (client *ClickHouseClient) AlterTableSettings(ctx context.Context, currentTable, desiredTable ClickHouseTable) error {
+// alterTable runs ALTER TABLE with the given clauses in a single query and waits for mutations it causes on all replicas.
+func (client *ClickHouseClient) alterTable(ctx context.Context, db, table string, clauses []string) error {
+	if len(clauses) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(
+		"ALTER TABLE %s.%s%s %s",
+		QuoteID(db),
+		QuoteID(table),
+		client.onCluster(),
+		strings.Join(clauses, ", "),
+	)
+	tflog.Info(ctx, "Altering a table", dict{"query": query})
+
+	return client.Conn.Exec(clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{
+		"mutations_sync": 2,
+	})), query)
+}
+
+// settingsClauses returns MODIFY SETTING and RESET SETTING clauses for settings which differ from the current ones.
+func settingsClauses(currentTable, desiredTable ClickHouseTable) []string {
 	if reflect.DeepEqual(currentTable.Settings, desiredTable.Settings) {
 		return nil
 	}
 
-	desiredSettingsSet := hashset.New[string]()
-	for k := range desiredTable.Settings {
-		desiredSettingsSet.Add(k)
+	changedSettings := make(map[string]string)
+	for k, v := range desiredTable.Settings {
+		if current, ok := currentTable.Settings[k]; !ok || current != v {
+			changedSettings[k] = v
+		}
 	}
 
-	currentSettingsSet := hashset.New[string]()
+	var resetSettings []string
 	for k := range currentTable.Settings {
-		currentSettingsSet.Add(k)
+		if _, ok := desiredTable.Settings[k]; !ok {
+			resetSettings = append(resetSettings, k)
+		}
 	}
+	sort.Strings(resetSettings)
 
-	err := client.ModifyTableSettings(ctx, desiredTable.Database, desiredTable.Name, desiredTable.Settings)
-	if err != nil {
-		return err
+	var clauses []string
+	if len(changedSettings) > 0 {
+		clauses = append(clauses, "MODIFY SETTING "+QuoteMapAndJoin(changedSettings))
+	}
+	if len(resetSettings) > 0 {
+		clauses = append(clauses, "RESET SETTING "+QuoteListWithTicksAndJoin(resetSettings))
 	}
 
-	resetSettings := currentSettingsSet.Difference(desiredSettingsSet)
-	return client.ResetTableSettings(ctx, desiredTable.Database, desiredTable.Name, resetSettings.Values()...)
+	return clauses
 }
 
-func (client *ClickHouseClient) ModifyTableSettings(ctx context.Context, db, table string, settings map[string]string) error {
-	if len(settings) == 0 {
-		return nil
+// columnClauses returns three batches of ALTER TABLE clauses which bring columns of the table to the desired state:
+// renames, removals of column properties and the rest of changes. Each batch is run as a single multi-clause query,
+// so that no clause depends on another one of the same query.
+func columnClauses(currentTable, desiredTable ClickHouseTable) ([3][]string, error) {
+	if reflect.DeepEqual(currentTable.Columns, desiredTable.Columns) {
+		return [3][]string{}, nil
 	}
 
-	query := fmt.Sprintf(
-		"ALTER TABLE %s.%s MODIFY SETTING %s",
-		QuoteID(db),
-		QuoteID(table),
-		QuoteMapAndJoin(settings),
-	)
-	tflog.Info(ctx, "Modifying table settings", dict{"query": query})
-
-	return client.Conn.Exec(ctx, query)
-}
+	renameClauses, currentCols := renameColumnClauses(currentTable.Columns, desiredTable.Columns)
+	dropClauses, currentCols, err := dropColumnClauses(currentCols, desiredTable)
+	if err != nil {
+		return [3][]string{}, err
+	}
 
-func (client *ClickHouseClient) ResetTableSettings(ctx context.Context, db, table string, settingsNames ...string) error {
-	if len(settingsNames) == 0 {
-		return nil
+	currentColsMap := make(map[string]ClickHouseColumn, len(currentCols))
+	for _, col := range currentCols {
+		currentColsMap[col.Name] = col
 	}
 
-	query := fmt.Sprintf(
-		"ALTER TABLE %s.%s RESET SETTING %s",
-		QuoteID(db),
-		QuoteID(table),
-		QuoteListWithTicksAndJoin(settingsNames),
-	)
-	tflog.Info(ctx, "Resetting table settings", dict{"query": query})
+	var removeClauses []string
+	clauses := dropClauses
+	order := currentCols.Names()
+	for i, col := range desiredTable.Columns {
+		position := " FIRST"
+		if i > 0 {
+			position = " AFTER " + QuoteID(desiredTable.Columns[i-1].Name)
+		}
 
-	return client.Conn.Exec(ctx, query)
+		currentCol, ok := currentColsMap[col.Name]
+		if !ok {
+			clauses = append(clauses, "ADD COLUMN "+col.String()+position)
+			order = slices.Insert(order, i, col.Name)
+			continue
+		}
+
+		if order[i] == col.Name {
+			position = ""
+		} else {
+			order = slices.Insert(slices.DeleteFunc(order, func(name string) bool { return name == col.Name }), i, col.Name)
+		}
+
+		removeClauses = append(removeClauses, removeColumnPropertyClauses(currentCol, col)...)
+		clauses = append(clauses, modifyColumnClauses(currentCol, col, position)...)
+	}
+
+	return [3][]string{renameClauses, removeClauses, clauses}, nil
 }
 
-func (client *ClickHouseClient) AlterColumns(ctx context.Context, currentTable, desiredTable ClickHouseTable) error {
-	if reflect.DeepEqual(currentTable.Columns, desiredTable.Columns) {
-		return nil
+// renameColumnClauses returns RENAME COLUMN clauses for columns which have PreviousName set
+// and current columns with new names.
+func renameColumnClauses(currentCols, desiredCols ClickHouseColumns) ([]string, ClickHouseColumns) {
+	currentIndexes := make(map[string]int, len(currentCols))
+	for i, col := range currentCols {
+		currentIndexes[col.Name] = i
 	}
 
+	var clauses []string
+	result := slices.Clone(currentCols)
+	for _, col := range desiredCols {
+		if col.PreviousName == "" || col.PreviousName == col.Name {
+			continue
+		}
+		if _, exists := currentIndexes[col.Name]; exists {
+			continue
+		}
+		i, ok := currentIndexes[col.PreviousName]
+		if !ok {
+			continue
+		}
+
+		clauses = append(clauses, fmt.Sprintf("RENAME COLUMN %s TO %s", QuoteID(col.PreviousName), QuoteID(col.Name)))
+		delete(currentIndexes, col.PreviousName)
+		currentIndexes[col.Name] = i
+		result[i].Name = col.Name
+	}
+
+	return clauses, result
+}
+
+// dropColumnClauses returns DROP COLUMN clauses for columns missing from the desired table if it is allowed
+// and remaining columns.
+func dropColumnClauses(currentCols ClickHouseColumns, desiredTable ClickHouseTable) ([]string, ClickHouseColumns, error) {
 	desiredColsSet := hashset.New[string](desiredTable.Columns.Names()...)
-	currentColsSet := hashset.New[string](currentTable.Columns.Names()...)
-	oldCols := currentColsSet.Intersection(desiredColsSet)
-	newCols := desiredColsSet.Difference(currentColsSet)
-	desiredColsMap := make(map[string]struct {
-		ClickHouseColumn
-		int
-	}, len(desiredTable.Columns))
-	for i, col := range desiredTable.Columns {
-		desiredColsMap[col.Name] = struct {
-			ClickHouseColumn
-			int
-		}{col, i}
+
+	var droppedCols []string
+	result := make(ClickHouseColumns, 0, len(currentCols))
+	for _, col := range currentCols {
+		if desiredColsSet.Contains(col.Name) {
+			result = append(result, col)
+		} else {
+			droppedCols = append(droppedCols, col.Name)
+		}
+	}
+	if len(droppedCols) == 0 {
+		return nil, result, nil
 	}
 
-	if !oldCols.Contains(currentColsSet.Values()...) {
-		return &NotSupportedError{
-			Operation: "renaming or removing columns",
+	if !desiredTable.AllowDropColumns {
+		return nil, nil, &NotSupportedError{
+			Operation: "removing columns",
 			Detail: fmt.Sprintf("cannot update columns of table %s.%s: "+
-				"desired config does not contain columns from previous config. "+
-				"If you did not try to rename or delete columns, it is a bug in the Client",
+				"columns %s are missing from the desired config. "+
+				"Set allow_drop_columns to drop them with their data or previous_name to rename them",
 				desiredTable.Database,
 				desiredTable.Name,
+				strings.Join(droppedCols, ", "),
 			),
 		}
 	}
 
-	for _, colName := range newCols.Values() {
-		colType := desiredColsMap[colName].Type
-		if desiredColsMap[colName].Nullable {
-			colType = "Nullable(" + colType + ")"
-		}
-		query := fmt.Sprintf(
-			`ALTER TABLE %s.%s ADD COLUMN %s %s COMMENT %s`,
-			QuoteID(desiredTable.Database),
-			QuoteID(desiredTable.Name),
-			QuoteID(colName),
-			colType,
-			QuoteValue(desiredColsMap[colName].Comment),
-		)
-		tflog.Info(ctx, "Adding a column", dict{"query": query})
-		err := client.Conn.Exec(ctx, query)
-		if err != nil {
-			return err
-		}
+	clauses := make([]string, 0, len(droppedCols))
+	for _, name := range droppedCols {
+		clauses = append(clauses, "DROP COLUMN "+QuoteID(name))
 	}
+	return clauses, result, nil
+}
 
-	for _, col := range desiredTable.Columns {
-		if newCols.Contains(col.Name) {
-			continue
-		}
+// removeColumnPropertyClauses returns clauses removing the default expression, codecs and TTL
+// which the current column has and the desired one does not.
+func removeColumnPropertyClauses(currentCol, desiredCol ClickHouseColumn) []string {
+	var clauses []string
+	name := QuoteID(desiredCol.Name)
 
-		colType := col.Type
-		if col.Nullable {
-			colType = "Nullable(" + colType + ")"
-		}
-		query := fmt.Sprintf(`ALTER TABLE %s.%s ALTER COLUMN %s TYPE %s COMMENT %s`,
-			QuoteID(desiredTable.Database),
-			QuoteID(desiredTable.Name),
-			QuoteID(col.Name),
-			colType,
-			QuoteValue(col.Comment),
-		)
-		tflog.Info(ctx, "Changing a column", dict{"query": query})
-		err := client.Conn.Exec(ctx, query)
-		if err != nil {
-			return err
-		}
+	if desiredCol.DefaultKind == "" && currentCol.DefaultKind != "" {
+		clauses = append(clauses, "MODIFY COLUMN "+name+" REMOVE "+currentCol.DefaultKind)
+	}
+	if desiredCol.Codec == "" && currentCol.Codec != "" {
+		clauses = append(clauses, "MODIFY COLUMN "+name+" REMOVE CODEC")
+	}
+	if desiredCol.TTL == "" && currentCol.TTL != "" {
+		clauses = append(clauses, "MODIFY COLUMN "+name+" REMOVE TTL")
 	}
 
-	for i := range desiredTable.Columns {
-		desiredCol := desiredTable.Columns[i]
-		if i < len(currentTable.Columns) {
-			currentCol := currentTable.Columns[i]
-			if desiredCol.Name == currentCol.Name {
-				continue
-			}
-		}
-		colType := desiredCol.Type
-		if desiredCol.Nullable {
-			colType = "Nullable(" + colType + ")"
-		}
-		desiredIdx := i
-		var query string
-
-		if desiredIdx == 0 {
-			query = fmt.Sprintf(
-				`ALTER TABLE %s.%s ALTER COLUMN %s TYPE %s FIRST`,
-				QuoteID(desiredTable.Database),
-				QuoteID(desiredTable.Name),
-				QuoteID(desiredCol.Name),
-				colType,
-			)
-		} else {
-			prevColName := desiredTable.Columns[desiredIdx-1].Name
-			query = fmt.Sprintf(
-				`ALTER TABLE %s.%s ALTER COLUMN %s TYPE %s AFTER %s`,
-				QuoteID(desiredTable.Database),
-				QuoteID(desiredTable.Name),
-				QuoteID(desiredCol.Name),
-				colType,
-				QuoteID(prevColName),
-			)
-		}
+	return clauses
+}
 
-		tflog.Info(ctx, "Changing column's order", dict{"query": query})
-		err := client.Conn.Exec(ctx, query)
-		if err != nil {
-			return err
+// modifyColumnClauses returns at most one MODIFY COLUMN clause with all changes of the column,
+// or a COMMENT COLUMN clause if only the comment is changed. Position is either empty, ` FIRST` or ` AFTER col`.
+// Unchanged columns produce no clauses, so that no data is rewritten.
+func modifyColumnClauses(currentCol, desiredCol ClickHouseColumn, position string) []string {
+	name := QuoteID(desiredCol.Name)
+	sameComment := currentCol.Comment == desiredCol.Comment
+
+	modified := position != "" ||
+		!SameColumnType(desiredCol.FullType(), currentCol.FullType()) ||
+		(desiredCol.DefaultKind != "" &&
+			(desiredCol.DefaultKind != currentCol.DefaultKind || !SameExpression(desiredCol.DefaultExpression, currentCol.DefaultExpression))) ||
+		(desiredCol.Codec != "" && !SameCodec(desiredCol.Codec, currentCol.Codec)) ||
+		(desiredCol.TTL != "" && !SameExpression(desiredCol.TTL, currentCol.TTL))
+	if !modified {
+		if sameComment {
+			return nil
 		}
+		return []string{"COMMENT COLUMN " + name + " " + QuoteValue(desiredCol.Comment)}
 	}
-	return nil
+
+	clause := "MODIFY COLUMN " + name + " " + desiredCol.typeAndDefault()
+	if !sameComment {
+		clause += " COMMENT " + QuoteValue(desiredCol.Comment)
+	}
+	if desiredCol.Codec != "" {
+		clause += " CODEC(" + desiredCol.Codec + ")"
+	}
+	if desiredCol.TTL != "" {
+		clause += " TTL " + desiredCol.TTL
+	}
+	return []string{clause + position}
 }
 
 func (client *ClickHouseClient) DropTable(ctx context.Context, table ClickHouseTable, checkEmpty bool) error {
@@ -553,9 +773,10 @@ func (client *ClickHouseClient) DropTable(ctx context.Context, table ClickHouseT
 	}
 
 	query := fmt.Sprintf(
-		`DROP TABLE %s.%s`,
+		`DROP TABLE %s.%s%s`,
 		QuoteID(table.Database),
 		QuoteID(table.Name),
+		client.onCluster(),
 	)
 	tflog.Info(ctx, "Dropping a table", dict{"query": query})
 	return client.Conn.Exec(ctx, query)
@@ -0,0 +1,102 @@
+package chclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ClickHouseConstraint is a constraint of a table.
+// See: https://clickhouse.com/docs/en/sql-reference/statements/create/table#constraints
+type ClickHouseConstraint struct {
+	Name string
+	// Type is either CHECK or ASSUME.
+	Type       string
+	Expression string
+}
+
+func (constraint ClickHouseConstraint) String() string {
+	return fmt.Sprintf(
+		"CONSTRAINT %s %s %s",
+		QuoteWithTicks(constraint.Name),
+		constraint.Type,
+		constraint.Expression,
+	)
+}
+
+// Same reports whether constraints are equal ignoring formatting differences introduced by ClickHouse.
+func (constraint ClickHouseConstraint) Same(other ClickHouseConstraint) bool {
+	return constraint.Name == other.Name &&
+		constraint.Type == other.Type &&
+		SameExpression(constraint.Expression, other.Expression)
+}
+
+// AlterConstraints drops constraints missing from the desired table and adds new ones. Changed constraints are recreated.
+func (client *ClickHouseClient) AlterConstraints(ctx context.Context, currentTable, desiredTable ClickHouseTable) error {
+	err := client.dropConstraints(ctx, currentTable, desiredTable)
+	if err != nil {
+		return err
+	}
+	return client.addConstraints(ctx, currentTable, desiredTable)
+}
+
+// dropConstraints drops constraints which are missing from the desired table or differ from the desired ones.
+func (client *ClickHouseClient) dropConstraints(ctx context.Context, currentTable, desiredTable ClickHouseTable) error {
+	desiredConstraints := make(map[string]ClickHouseConstraint, len(desiredTable.Constraints))
+	for _, constraint := range desiredTable.Constraints {
+		desiredConstraints[constraint.Name] = constraint
+	}
+
+	for _, constraint := range currentTable.Constraints {
+		desired, ok := desiredConstraints[constraint.Name]
+		if ok && desired.Same(constraint) {
+			continue
+		}
+
+		query := fmt.Sprintf(
+			"ALTER TABLE %s.%s%s DROP CONSTRAINT %s",
+			QuoteID(desiredTable.Database),
+			QuoteID(desiredTable.Name),
+			client.onCluster(),
+			QuoteID(constraint.Name),
+		)
+		tflog.Info(ctx, "Dropping a constraint", dict{"query": query})
+		err := client.Conn.Exec(ctx, query)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addConstraints adds constraints which are missing from the current table or differ from the current ones.
+func (client *ClickHouseClient) addConstraints(ctx context.Context, currentTable, desiredTable ClickHouseTable) error {
+	currentConstraints := make(map[string]ClickHouseConstraint, len(currentTable.Constraints))
+	for _, constraint := range currentTable.Constraints {
+		currentConstraints[constraint.Name] = constraint
+	}
+
+	for _, constraint := range desiredTable.Constraints {
+		current, ok := currentConstraints[constraint.Name]
+		if ok && current.Same(constraint) {
+			continue
+		}
+
+		query := fmt.Sprintf(
+			"ALTER TABLE %s.%s%s ADD %s",
+			QuoteID(desiredTable.Database),
+			QuoteID(desiredTable.Name),
+			client.onCluster(),
+			constraint.String(),
+		)
+		tflog.Info(ctx, "Adding a constraint", dict{"query": query})
+		err := client.Conn.Exec(ctx, query)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package chclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestAlterConstraints(t *testing.T) {
+	positive := ClickHouseConstraint{Name: "c_positive", Type: "CHECK", Expression: "amount >= 0"}
+	currency := ClickHouseConstraint{Name: "c_currency", Type: "ASSUME", Expression: "currency != ''"}
+
+	testCases := []struct {
+		name     string
+		current  []ClickHouseConstraint
+		desired  []ClickHouseConstraint
+		expected []string
+	}{
+		{
+			name:    "Same constraints",
+			current: []ClickHouseConstraint{{Name: "c_positive", Type: "CHECK", Expression: "amount>=0"}},
+			desired: []ClickHouseConstraint{positive},
+		},
+		{
+			name:     "Constraint is added",
+			current:  []ClickHouseConstraint{positive},
+			desired:  []ClickHouseConstraint{positive, currency},
+			expected: []string{"ALTER TABLE \"db\".\"table\" ADD CONSTRAINT `c_currency` ASSUME currency != ''"},
+		},
+		{
+			name:    "Constraint type is changed and another one is dropped",
+			current: []ClickHouseConstraint{positive, currency},
+			desired: []ClickHouseConstraint{{Name: "c_currency", Type: "CHECK", Expression: "currency != ''"}},
+			expected: []string{
+				`ALTER TABLE "db"."table" DROP CONSTRAINT "c_positive"`,
+				`ALTER TABLE "db"."table" DROP CONSTRAINT "c_currency"`,
+				"ALTER TABLE \"db\".\"table\" ADD CONSTRAINT `c_currency` CHECK currency != ''",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			conn := mock_driver.NewMockConn(mockCtrl)
+			var calls []*gomock.Call
+			for _, query := range tc.expected {
+				calls = append(calls, conn.EXPECT().Exec(ctx, query).Return(nil).Times(1))
+			}
+			gomock.InOrder(calls...)
+
+			client := ClickHouseClient{Conn: conn}
+			current := ClickHouseTable{Database: "db", Name: "table", Constraints: tc.current}
+			desired := ClickHouseTable{Database: "db", Name: "table", Constraints: tc.desired}
+			if err := client.AlterConstraints(ctx, current, desired); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package chclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ClickHouseIndex is a data skipping index of a table.
+// See: https://clickhouse.com/docs/en/engines/table-engines/mergetree-family/mergetree#table_engine-mergetree-data_skipping-indexes
+type ClickHouseIndex struct {
+	Name       string
+	Expression string
+	// Type is the index type with parameters, e.g. `bloom_filter(0.01)`.
+	Type        string
+	Granularity uint64
+}
+
+func (index ClickHouseIndex) String() string {
+	return fmt.Sprintf(
+		"INDEX %s %s TYPE %s GRANULARITY %d",
+		QuoteWithTicks(index.Name),
+		index.Expression,
+		index.Type,
+		index.Granularity,
+	)
+}
+
+// Same reports whether indexes are equal ignoring formatting differences introduced by ClickHouse.
+func (index ClickHouseIndex) Same(other ClickHouseIndex) bool {
+	return index.Name == other.Name &&
+		SameExpression(index.Expression, other.Expression) &&
+		SameExpression(index.Type, other.Type) &&
+		index.Granularity == other.Granularity
+}
+
+func (client *ClickHouseClient) GetIndexes(ctx context.Context, database, table string) ([]ClickHouseIndex, error) {
+	query := fmt.Sprintf(
+		`SELECT "name", "expr", "type_full", "granularity" FROM "system"."data_skipping_indices"
+WHERE "database" = %s AND "table" = %s`,
+		QuoteValue(database),
+		QuoteValue(table),
+	)
+	tflog.Info(ctx, "Looking for table indexes", dict{"query": query})
+
+	rows, err := client.Conn.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var indexes []ClickHouseIndex
+	for rows.Next() {
+		var index ClickHouseIndex
+		err := rows.Scan(&index.Name, &index.Expression, &index.Type, &index.Granularity)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return indexes, nil
+}
+
+// AlterIndexes drops indexes missing from the desired table and adds new ones. Changed indexes are recreated.
+func (client *ClickHouseClient) AlterIndexes(ctx context.Context, currentTable, desiredTable ClickHouseTable) error {
+	err := client.dropIndexes(ctx, currentTable, desiredTable)
+	if err != nil {
+		return err
+	}
+	return client.addIndexes(ctx, currentTable, desiredTable)
+}
+
+// dropIndexes drops indexes which are missing from the desired table or differ from the desired ones.
+func (client *ClickHouseClient) dropIndexes(ctx context.Context, currentTable, desiredTable ClickHouseTable) error {
+	desiredIndexes := make(map[string]ClickHouseIndex, len(desiredTable.Indexes))
+	for _, index := range desiredTable.Indexes {
+		desiredIndexes[index.Name] = index
+	}
+
+	for _, index := range currentTable.Indexes {
+		desired, ok := desiredIndexes[index.Name]
+		if ok && desired.Same(index) {
+			continue
+		}
+
+		query := fmt.Sprintf(
+			"ALTER TABLE %s.%s%s DROP INDEX %s",
+			QuoteID(desiredTable.Database),
+			QuoteID(desiredTable.Name),
+			client.onCluster(),
+			QuoteID(index.Name),
+		)
+		tflog.Info(ctx, "Dropping an index", dict{"query": query})
+		err := client.Conn.Exec(ctx, query)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addIndexes adds indexes which are missing from the current table or differ from the current ones.
+func (client *ClickHouseClient) addIndexes(ctx context.Context, currentTable, desiredTable ClickHouseTable) error {
+	currentIndexes := make(map[string]ClickHouseIndex, len(currentTable.Indexes))
+	for _, index := range currentTable.Indexes {
+		currentIndexes[index.Name] = index
+	}
+
+	for _, index := range desiredTable.Indexes {
+		current, ok := currentIndexes[index.Name]
+		if ok && current.Same(index) {
+			continue
+		}
+
+		query := fmt.Sprintf(
+			"ALTER TABLE %s.%s%s ADD %s",
+			QuoteID(desiredTable.Database),
+			QuoteID(desiredTable.Name),
+			client.onCluster(),
+			index.String(),
+		)
+		tflog.Info(ctx, "Adding an index", dict{"query": query})
+		err := client.Conn.Exec(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		if !desiredTable.MaterializeIndexes {
+			continue
+		}
+
+		query = fmt.Sprintf(
+			"ALTER TABLE %s.%s%s MATERIALIZE INDEX %s",
+			QuoteID(desiredTable.Database),
+			QuoteID(desiredTable.Name),
+			client.onCluster(),
+			QuoteID(index.Name),
+		)
+		tflog.Info(ctx, "Materializing an index", dict{"query": query})
+		err = client.Conn.Exec(ctx, query)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package chclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestIndexString(t *testing.T) {
+	index := ClickHouseIndex{Name: "idx_user", Expression: "user_id", Type: "bloom_filter(0.01)", Granularity: 4}
+	expected := "INDEX `idx_user` user_id TYPE bloom_filter(0.01) GRANULARITY 4"
+
+	if actual := index.String(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestAlterIndexes(t *testing.T) {
+	userIndex := ClickHouseIndex{Name: "idx_user", Expression: "user_id", Type: "bloom_filter", Granularity: 4}
+	valueIndex := ClickHouseIndex{Name: "idx_value", Expression: "value * 2", Type: "minmax", Granularity: 1}
+
+	testCases := []struct {
+		name        string
+		current     []ClickHouseIndex
+		desired     []ClickHouseIndex
+		materialize bool
+		expected    []string
+	}{
+		{
+			name:    "Same indexes",
+			current: []ClickHouseIndex{{Name: "idx_value", Expression: "value*2", Type: "minmax", Granularity: 1}},
+			desired: []ClickHouseIndex{valueIndex},
+		},
+		{
+			name:    "Index is added and materialized",
+			current: []ClickHouseIndex{valueIndex},
+			desired: []ClickHouseIndex{valueIndex, userIndex},
+			expected: []string{
+				"ALTER TABLE \"db\".\"table\" ADD INDEX `idx_user` user_id TYPE bloom_filter GRANULARITY 4",
+				`ALTER TABLE "db"."table" MATERIALIZE INDEX "idx_user"`,
+			},
+			materialize: true,
+		},
+		{
+			name:     "Index is dropped",
+			current:  []ClickHouseIndex{valueIndex, userIndex},
+			desired:  []ClickHouseIndex{userIndex},
+			expected: []string{`ALTER TABLE "db"."table" DROP INDEX "idx_value"`},
+		},
+		{
+			name:    "Index is changed",
+			current: []ClickHouseIndex{userIndex},
+			desired: []ClickHouseIndex{{Name: "idx_user", Expression: "user_id", Type: "bloom_filter", Granularity: 8}},
+			expected: []string{
+				`ALTER TABLE "db"."table" DROP INDEX "idx_user"`,
+				"ALTER TABLE \"db\".\"table\" ADD INDEX `idx_user` user_id TYPE bloom_filter GRANULARITY 8",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			conn := mock_driver.NewMockConn(mockCtrl)
+			var calls []*gomock.Call
+			for _, query := range tc.expected {
+				calls = append(calls, conn.EXPECT().Exec(ctx, query).Return(nil).Times(1))
+			}
+			gomock.InOrder(calls...)
+
+			client := ClickHouseClient{Conn: conn}
+			current := ClickHouseTable{Database: "db", Name: "table", Indexes: tc.current}
+			desired := ClickHouseTable{Database: "db", Name: "table", Indexes: tc.desired, MaterializeIndexes: tc.materialize}
+			if err := client.AlterIndexes(ctx, current, desired); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package chclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ClickHouseProjection is a projection of a table.
+// See: https://clickhouse.com/docs/en/sql-reference/statements/alter/projection
+type ClickHouseProjection struct {
+	Name string
+	// Query is the SELECT body of the projection, e.g. `SELECT * ORDER BY user_id`.
+	Query string
+}
+
+func (projection ClickHouseProjection) String() string {
+	return fmt.Sprintf("PROJECTION %s (%s)", QuoteWithTicks(projection.Name), projection.Query)
+}
+
+// Same reports whether projections are equal ignoring formatting differences introduced by ClickHouse.
+func (projection ClickHouseProjection) Same(other ClickHouseProjection) bool {
+	return projection.Name == other.Name && SameExpression(projection.Query, other.Query)
+}
+
+// GetProjections reads projections of a table from system.projections.
+// Servers without this table report projections in create_table_query only, so it is parsed instead.
+func (client *ClickHouseClient) GetProjections(ctx context.Context, database, table, createTableQuery string) ([]ClickHouseProjection, error) {
+	exists, err := client.hasSystemTable(ctx, "projections")
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return parseProjections(createTableQuery), nil
+	}
+
+	query := fmt.Sprintf(
+		`SELECT "name", "query" FROM "system"."projections"
+WHERE "database" = %s AND "table" = %s`,
+		QuoteValue(database),
+		QuoteValue(table),
+	)
+	tflog.Info(ctx, "Looking for table projections", dict{"query": query})
+
+	rows, err := client.Conn.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var projections []ClickHouseProjection
+	for rows.Next() {
+		var projection ClickHouseProjection
+		err := rows.Scan(&projection.Name, &projection.Query)
+		if err != nil {
+			return nil, err
+		}
+		projections = append(projections, projection)
+	}
+
+	return projections, nil
+}
+
+// AlterProjections drops projections missing from the desired table and adds new ones. Changed projections are recreated.
+func (client *ClickHouseClient) AlterProjections(ctx context.Context, currentTable, desiredTable ClickHouseTable) error {
+	err := client.dropProjections(ctx, currentTable, desiredTable)
+	if err != nil {
+		return err
+	}
+	return client.addProjections(ctx, currentTable, desiredTable)
+}
+
+// dropProjections drops projections which are missing from the desired table or differ from the desired ones.
+func (client *ClickHouseClient) dropProjections(ctx context.Context, currentTable, desiredTable ClickHouseTable) error {
+	desiredProjections := make(map[string]ClickHouseProjection, len(desiredTable.Projections))
+	for _, projection := range desiredTable.Projections {
+		desiredProjections[projection.Name] = projection
+	}
+
+	for _, projection := range currentTable.Projections {
+		desired, ok := desiredProjections[projection.Name]
+		if ok && desired.Same(projection) {
+			continue
+		}
+
+		query := fmt.Sprintf(
+			"ALTER TABLE %s.%s%s DROP PROJECTION %s",
+			QuoteID(desiredTable.Database),
+			QuoteID(desiredTable.Name),
+			client.onCluster(),
+			QuoteID(projection.Name),
+		)
+		tflog.Info(ctx, "Dropping a projection", dict{"query": query})
+		err := client.Conn.Exec(ctx, query)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addProjections adds projections which are missing from the current table or differ from the current ones.
+func (client *ClickHouseClient) addProjections(ctx context.Context, currentTable, desiredTable ClickHouseTable) error {
+	currentProjections := make(map[string]ClickHouseProjection, len(currentTable.Projections))
+	for _, projection := range currentTable.Projections {
+		currentProjections[projection.Name] = projection
+	}
+
+	for _, projection := range desiredTable.Projections {
+		current, ok := currentProjections[projection.Name]
+		if ok && current.Same(projection) {
+			continue
+		}
+
+		query := fmt.Sprintf(
+			"ALTER TABLE %s.%s%s ADD %s",
+			QuoteID(desiredTable.Database),
+			QuoteID(desiredTable.Name),
+			client.onCluster(),
+			projection.String(),
+		)
+		tflog.Info(ctx, "Adding a projection", dict{"query": query})
+		err := client.Conn.Exec(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		if !desiredTable.MaterializeProjections {
+			continue
+		}
+
+		query = fmt.Sprintf(
+			"ALTER TABLE %s.%s%s MATERIALIZE PROJECTION %s",
+			QuoteID(desiredTable.Database),
+			QuoteID(desiredTable.Name),
+			client.onCluster(),
+			QuoteID(projection.Name),
+		)
+		tflog.Info(ctx, "Materializing a projection", dict{"query": query})
+		err = client.Conn.Exec(ctx, query)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (client *ClickHouseClient) hasSystemTable(ctx context.Context, name string) (bool, error) {
+	query := fmt.Sprintf(
+		`SELECT count() FROM "system"."tables" WHERE "database" = 'system' AND "name" = %s`,
+		QuoteValue(name),
+	)
+	tflog.Info(ctx, "Checking if a system table exists", dict{"query": query})
+
+	rows, err := client.Conn.Query(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var count uint64
+	if rows.Next() {
+		err = rows.Scan(&count)
+		if err != nil {
+			return false, err
+		}
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,70 @@
+package chclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestAlterProjections(t *testing.T) {
+	byUser := ClickHouseProjection{Name: "by_user", Query: "SELECT * ORDER BY user_id"}
+	totals := ClickHouseProjection{Name: "totals", Query: "SELECT user_id, count() GROUP BY user_id"}
+
+	testCases := []struct {
+		name        string
+		current     []ClickHouseProjection
+		desired     []ClickHouseProjection
+		materialize bool
+		expected    []string
+	}{
+		{
+			name:    "Same projections",
+			current: []ClickHouseProjection{{Name: "by_user", Query: "SELECT *\nORDER BY user_id"}},
+			desired: []ClickHouseProjection{byUser},
+		},
+		{
+			name:        "Projection is added and materialized",
+			current:     []ClickHouseProjection{byUser},
+			desired:     []ClickHouseProjection{byUser, totals},
+			materialize: true,
+			expected: []string{
+				"ALTER TABLE \"db\".\"table\" ADD PROJECTION `totals` (SELECT user_id, count() GROUP BY user_id)",
+				`ALTER TABLE "db"."table" MATERIALIZE PROJECTION "totals"`,
+			},
+		},
+		{
+			name:    "Projection is changed and another one is dropped",
+			current: []ClickHouseProjection{byUser, totals},
+			desired: []ClickHouseProjection{{Name: "by_user", Query: "SELECT * ORDER BY (user_id, ts)"}},
+			expected: []string{
+				`ALTER TABLE "db"."table" DROP PROJECTION "by_user"`,
+				`ALTER TABLE "db"."table" DROP PROJECTION "totals"`,
+				"ALTER TABLE \"db\".\"table\" ADD PROJECTION `by_user` (SELECT * ORDER BY (user_id, ts))",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			conn := mock_driver.NewMockConn(mockCtrl)
+			var calls []*gomock.Call
+			for _, query := range tc.expected {
+				calls = append(calls, conn.EXPECT().Exec(ctx, query).Return(nil).Times(1))
+			}
+			gomock.InOrder(calls...)
+
+			client := ClickHouseClient{Conn: conn}
+			current := ClickHouseTable{Database: "db", Name: "table", Projections: tc.current}
+			desired := ClickHouseTable{Database: "db", Name: "table", Projections: tc.desired, MaterializeProjections: tc.materialize}
+			if err := client.AlterProjections(ctx, current, desired); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
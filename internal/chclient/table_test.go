@@ -0,0 +1,350 @@
+package chclient
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestColumnString(t *testing.T) {
+	testCases := []struct {
+		column   ClickHouseColumn
+		expected string
+	}{
+		{
+			column:   ClickHouseColumn{Name: "id", Type: "UInt64"},
+			expected: "`id` UInt64",
+		},
+		{
+			column:   ClickHouseColumn{Name: "value", Type: "Float64", Nullable: true, Comment: "value"},
+			expected: "`value` Nullable(Float64) COMMENT 'value'",
+		},
+		{
+			column:   ClickHouseColumn{Name: "date", Type: "Date", DefaultKind: "MATERIALIZED", DefaultExpression: "toDate(ts)"},
+			expected: "`date` Date MATERIALIZED toDate(ts)",
+		},
+		{
+			column:   ClickHouseColumn{Name: "raw", Type: "String", DefaultKind: "EPHEMERAL"},
+			expected: "`raw` String EPHEMERAL",
+		},
+		{
+			column:   ClickHouseColumn{Name: "payload", Type: "String", Comment: "c", Codec: "ZSTD(3)", TTL: "ts + INTERVAL 1 DAY"},
+			expected: "`payload` String COMMENT 'c' CODEC(ZSTD(3)) TTL ts + INTERVAL 1 DAY",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if actual := tc.column.String(); actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAlterColumnDefault(t *testing.T) {
+	testCases := []struct {
+		name     string
+		current  ClickHouseColumn
+		desired  ClickHouseColumn
+		expected string
+	}{
+		{
+			name:     "Default is added",
+			current:  ClickHouseColumn{Name: "source", Type: "String"},
+			desired:  ClickHouseColumn{Name: "source", Type: "String", DefaultKind: "DEFAULT", DefaultExpression: "'unknown'"},
+			expected: `MODIFY COLUMN "source" String DEFAULT 'unknown'`,
+		},
+		{
+			name:     "Default kind is changed",
+			current:  ClickHouseColumn{Name: "date", Type: "Date", DefaultKind: "MATERIALIZED", DefaultExpression: "toDate(ts)"},
+			desired:  ClickHouseColumn{Name: "date", Type: "Date", DefaultKind: "DEFAULT", DefaultExpression: "toDate(ts)"},
+			expected: `MODIFY COLUMN "date" Date DEFAULT toDate(ts)`,
+		},
+		{
+			name:     "Default is removed",
+			current:  ClickHouseColumn{Name: "date", Type: "Date", DefaultKind: "MATERIALIZED", DefaultExpression: "toDate(ts)"},
+			desired:  ClickHouseColumn{Name: "date", Type: "Date"},
+			expected: `MODIFY COLUMN "date" REMOVE MATERIALIZED`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := append(removeColumnPropertyClauses(tc.current, tc.desired), modifyColumnClauses(tc.current, tc.desired, "")...)
+			if !reflect.DeepEqual(actual, []string{tc.expected}) {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAlterColumnCodecAndTTL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		current  ClickHouseColumn
+		desired  ClickHouseColumn
+		expected []string
+	}{
+		{
+			name:    "Same codec with default parameters",
+			current: ClickHouseColumn{Name: "v", Type: "UInt64", Codec: "Delta(8), ZSTD(1)"},
+			desired: ClickHouseColumn{Name: "v", Type: "UInt64", Codec: "Delta, ZSTD"},
+		},
+		{
+			name:     "Codec is changed",
+			current:  ClickHouseColumn{Name: "v", Type: "UInt64", Codec: "ZSTD(1)"},
+			desired:  ClickHouseColumn{Name: "v", Type: "UInt64", Codec: "Delta, ZSTD(3)"},
+			expected: []string{`MODIFY COLUMN "v" UInt64 CODEC(Delta, ZSTD(3))`},
+		},
+		{
+			name:     "Codec is removed",
+			current:  ClickHouseColumn{Name: "v", Type: "UInt64", Codec: "ZSTD(1)"},
+			desired:  ClickHouseColumn{Name: "v", Type: "UInt64"},
+			expected: []string{`MODIFY COLUMN "v" REMOVE CODEC`},
+		},
+		{
+			name:    "Same TTL",
+			current: ClickHouseColumn{Name: "v", Type: "UInt64", TTL: "ts + toIntervalDay(1)"},
+			desired: ClickHouseColumn{Name: "v", Type: "UInt64", TTL: "ts + INTERVAL 1 DAY"},
+		},
+		{
+			name:     "TTL is changed",
+			current:  ClickHouseColumn{Name: "v", Type: "UInt64"},
+			desired:  ClickHouseColumn{Name: "v", Type: "UInt64", TTL: "ts + INTERVAL 1 DAY"},
+			expected: []string{`MODIFY COLUMN "v" UInt64 TTL ts + INTERVAL 1 DAY`},
+		},
+		{
+			name:     "TTL is removed",
+			current:  ClickHouseColumn{Name: "v", Type: "UInt64", TTL: "ts + toIntervalDay(1)"},
+			desired:  ClickHouseColumn{Name: "v", Type: "UInt64"},
+			expected: []string{`MODIFY COLUMN "v" REMOVE TTL`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := append(removeColumnPropertyClauses(tc.current, tc.desired), modifyColumnClauses(tc.current, tc.desired, "")...)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestQuoteKeyExpressions(t *testing.T) {
+	actual := quoteKeyExpressions([]string{"user_id", "toStartOfHour(ts)", "cityHash64(user_id)"})
+	expected := "`user_id`, toStartOfHour(ts), cityHash64(user_id)"
+
+	if actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestAlterColumnsRenameAndDrop(t *testing.T) {
+	current := ClickHouseTable{
+		Database: "db",
+		Name:     "table",
+		Columns: ClickHouseColumns{
+			{Name: "id", Type: "UInt64"},
+			{Name: "old", Type: "String"},
+			{Name: "gone", Type: "String"},
+		},
+	}
+	desired := ClickHouseTable{
+		Database: "db",
+		Name:     "table",
+		Columns: ClickHouseColumns{
+			{Name: "id", Type: "UInt64"},
+			{Name: "new", Type: "String", PreviousName: "old"},
+		},
+	}
+
+	t.Run("Drop is not allowed", func(t *testing.T) {
+		ctx := context.Background()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		conn := mock_driver.NewMockConn(mockCtrl)
+
+		client := ClickHouseClient{Conn: conn}
+		err := client.alterTableTo(ctx, current, desired)
+
+		var notSupportedError *NotSupportedError
+		if !errors.As(err, &notSupportedError) {
+			t.Fatalf("expected NotSupportedError, got %v", err)
+		}
+	})
+
+	t.Run("Drop is allowed", func(t *testing.T) {
+		ctx := context.Background()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		conn := mock_driver.NewMockConn(mockCtrl)
+		gomock.InOrder(
+			conn.EXPECT().Exec(gomock.Any(), `ALTER TABLE "db"."table" RENAME COLUMN "old" TO "new"`).Return(nil).Times(1),
+			conn.EXPECT().Exec(gomock.Any(), `ALTER TABLE "db"."table" DROP COLUMN "gone"`).Return(nil).Times(1),
+		)
+
+		client := ClickHouseClient{Conn: conn}
+		desired.AllowDropColumns = true
+		if err := client.alterTableTo(ctx, current, desired); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestAlterColumnsBatch(t *testing.T) {
+	current := ClickHouseTable{
+		Database: "db",
+		Name:     "table",
+		Columns: ClickHouseColumns{
+			{Name: "id", Type: "UInt64"},
+			{Name: "value", Type: "Int32", Codec: "ZSTD(1)"},
+			{Name: "note", Type: "String"},
+			{Name: "ts", Type: "DateTime"},
+		},
+	}
+	desired := ClickHouseTable{
+		Database: "db",
+		Name:     "table",
+		Columns: ClickHouseColumns{
+			{Name: "id", Type: "UInt64"},
+			{Name: "ts", Type: "DateTime"},
+			{Name: "added", Type: "String"},
+			{Name: "value", Type: "Int64"},
+			{Name: "note", Type: "String", Comment: "free text"},
+		},
+	}
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	conn := mock_driver.NewMockConn(mockCtrl)
+	gomock.InOrder(
+		conn.EXPECT().Exec(gomock.Any(), `ALTER TABLE "db"."table" MODIFY COLUMN "value" REMOVE CODEC`).Return(nil).Times(1),
+		conn.EXPECT().Exec(gomock.Any(), `ALTER TABLE "db"."table" `+
+			`MODIFY COLUMN "ts" DateTime AFTER "id", `+
+			"ADD COLUMN `added` String AFTER \"ts\", "+
+			`MODIFY COLUMN "value" Int64, `+
+			`COMMENT COLUMN "note" 'free text'`,
+		).Return(nil).Times(1),
+	)
+
+	client := ClickHouseClient{Conn: conn}
+	if err := client.alterTableTo(ctx, current, desired); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAlterTableSettings(t *testing.T) {
+	current := ClickHouseTable{
+		Database: "db",
+		Name:     "table",
+		Settings: map[string]string{"index_granularity": "8192", "ttl_only_drop_parts": "1", "min_bytes_for_wide_part": "0"},
+	}
+	desired := ClickHouseTable{
+		Database: "db",
+		Name:     "table",
+		Settings: map[string]string{"index_granularity": "8192", "ttl_only_drop_parts": "0"},
+	}
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	conn := mock_driver.NewMockConn(mockCtrl)
+	conn.EXPECT().Exec(
+		gomock.Any(),
+		"ALTER TABLE \"db\".\"table\" MODIFY SETTING `ttl_only_drop_parts` = '0', RESET SETTING `min_bytes_for_wide_part`",
+	).Return(nil).Times(1)
+
+	client := ClickHouseClient{Conn: conn}
+	if err := client.alterTableTo(ctx, current, desired); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAlterTableDropsObsoleteObjectsBeforeColumns(t *testing.T) {
+	current := ClickHouseTable{
+		Database: "db",
+		Name:     "table",
+		Columns: ClickHouseColumns{
+			{Name: "id", Type: "UInt64"},
+			{Name: "value", Type: "Int32"},
+		},
+		Indexes: []ClickHouseIndex{{Name: "idx_value", Expression: "value * 2", Type: "minmax", Granularity: 1}},
+	}
+	desired := ClickHouseTable{
+		Database: "db",
+		Name:     "table",
+		Columns: ClickHouseColumns{
+			{Name: "id", Type: "UInt64"},
+			{Name: "value", Type: "Int64"},
+			{Name: "user_id", Type: "UInt64"},
+		},
+		Indexes: []ClickHouseIndex{{Name: "idx_user", Expression: "user_id", Type: "bloom_filter", Granularity: 4}},
+	}
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	conn := mock_driver.NewMockConn(mockCtrl)
+	gomock.InOrder(
+		conn.EXPECT().Exec(gomock.Any(), `ALTER TABLE "db"."table" DROP INDEX "idx_value"`).Return(nil).Times(1),
+		conn.EXPECT().Exec(gomock.Any(), `ALTER TABLE "db"."table" `+
+			`MODIFY COLUMN "value" Int64, `+
+			"ADD COLUMN `user_id` UInt64 AFTER \"value\"",
+		).Return(nil).Times(1),
+		conn.EXPECT().Exec(gomock.Any(), "ALTER TABLE \"db\".\"table\" ADD INDEX `idx_user` user_id TYPE bloom_filter GRANULARITY 4").Return(nil).Times(1),
+	)
+
+	client := ClickHouseClient{Conn: conn}
+	if err := client.alterTableTo(ctx, current, desired); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAlterTableBatchesSettingsWithColumns(t *testing.T) {
+	current := ClickHouseTable{
+		Database: "db",
+		Name:     "table",
+		OrderBy:  []string{"id", "toStartOfHour(ts)"},
+		Columns: ClickHouseColumns{
+			{Name: "id", Type: "UInt64"},
+			{Name: "ts", Type: "DateTime"},
+			{Name: "value", Type: "Int32"},
+		},
+		Settings: map[string]string{"index_granularity": "8192"},
+	}
+	desired := current
+	desired.OrderBy = []string{"id", "toStartOfHour( ts )"}
+	desired.Columns = ClickHouseColumns{
+		{Name: "id", Type: "UInt64"},
+		{Name: "ts", Type: "DateTime"},
+		{Name: "value", Type: "Int64"},
+	}
+	desired.Settings = map[string]string{"index_granularity": "8192", "ttl_only_drop_parts": "1"}
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	conn := mock_driver.NewMockConn(mockCtrl)
+	conn.EXPECT().Exec(
+		gomock.Any(),
+		"ALTER TABLE \"db\".\"table\" MODIFY COLUMN \"value\" Int64, MODIFY SETTING `ttl_only_drop_parts` = '1'",
+	).Return(nil).Times(1)
+
+	client := ClickHouseClient{Conn: conn}
+	if err := client.alterTableTo(ctx, current, desired); err != nil {
+		t.Fatal(err)
+	}
+}
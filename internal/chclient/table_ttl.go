@@ -0,0 +1,262 @@
+package chclient
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ClickHouseTTLRule is a table TTL rule. A rule without a target deletes expired rows.
+// See: https://clickhouse.com/docs/en/engines/table-engines/mergetree-family/mergetree#table_engine-mergetree-ttl
+type ClickHouseTTLRule struct {
+	Expression      string
+	ToDisk          string
+	ToVolume        string
+	RecompressCodec string
+	Where           string
+	GroupBy         []string
+	// Set maps columns to aggregate expressions for GROUP BY rules, e.g. `v` to `sum(v)`.
+	Set map[string]string
+}
+
+func (rule ClickHouseTTLRule) String() string {
+	result := rule.Expression
+
+	switch {
+	case rule.ToDisk != "":
+		result += " TO DISK " + QuoteValue(rule.ToDisk)
+	case rule.ToVolume != "":
+		result += " TO VOLUME " + QuoteValue(rule.ToVolume)
+	case rule.RecompressCodec != "":
+		result += " RECOMPRESS CODEC(" + rule.RecompressCodec + ")"
+	}
+
+	if rule.Where != "" {
+		result += " WHERE " + rule.Where
+	}
+
+	if len(rule.GroupBy) > 0 {
+		result += " GROUP BY " + strings.Join(rule.GroupBy, ", ")
+
+		if len(rule.Set) > 0 {
+			columns := make([]string, 0, len(rule.Set))
+			for column := range rule.Set {
+				columns = append(columns, column)
+			}
+			sort.Strings(columns)
+
+			assignments := make([]string, 0, len(columns))
+			for _, column := range columns {
+				assignments = append(assignments, column+" = "+rule.Set[column])
+			}
+			result += " SET " + strings.Join(assignments, ", ")
+		}
+	}
+
+	return result
+}
+
+// Same reports whether rules are equal ignoring formatting differences introduced by ClickHouse.
+func (rule ClickHouseTTLRule) Same(other ClickHouseTTLRule) bool {
+	if !SameExpression(rule.Expression, other.Expression) ||
+		rule.ToDisk != other.ToDisk ||
+		rule.ToVolume != other.ToVolume ||
+		!SameCodec(rule.RecompressCodec, other.RecompressCodec) ||
+		!SameExpression(rule.Where, other.Where) ||
+		len(rule.GroupBy) != len(other.GroupBy) ||
+		len(rule.Set) != len(other.Set) {
+		return false
+	}
+
+	for i := range rule.GroupBy {
+		if !SameExpression(rule.GroupBy[i], other.GroupBy[i]) {
+			return false
+		}
+	}
+
+	for column, expr := range rule.Set {
+		if !SameExpression(expr, other.Set[column]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SameTTL reports whether lists of TTL rules are equal ignoring formatting differences.
+func SameTTL(a, b []ClickHouseTTLRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Same(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func ttlQuery(rules []ClickHouseTTLRule) string {
+	result := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, rule.String())
+	}
+	return strings.Join(result, ", ")
+}
+
+var (
+	ttlIdentifierRegexp = regexp.MustCompile("^(`[^`]+`|[A-Za-z_][A-Za-z0-9_]*)$")
+	ttlAssignmentRegexp = regexp.MustCompile("^(`[^`]+`|[A-Za-z_][A-Za-z0-9_]*)\\s*=\\s*(.+)$")
+)
+
+// parseTableTTL extracts TTL rules from the engine_full column of system.tables.
+func parseTableTTL(engineFull string) []ClickHouseTTLRule {
+	index := indexTopLevelKeyword(engineFull, "TTL")
+	if index == -1 {
+		return nil
+	}
+	ttl := engineFull[index+len("TTL"):]
+	if settingsIndex := indexTopLevelKeyword(ttl, "SETTINGS"); settingsIndex != -1 {
+		ttl = ttl[:settingsIndex]
+	}
+
+	var rules []ClickHouseTTLRule
+	// GROUP BY keys and SET assignments are separated with commas just like rules,
+	// so parts are attached to the previous GROUP BY rule while they look like keys or assignments.
+	inGroupBy, inSet := false, false
+	for _, part := range splitTopLevel(ttl) {
+		last := len(rules) - 1
+
+		if inSet {
+			if match := ttlAssignmentRegexp.FindStringSubmatch(part); match != nil {
+				rules[last].Set[unquoteTicks(match[1])] = strings.TrimSpace(match[2])
+				continue
+			}
+		}
+		if inGroupBy && !inSet {
+			key, rest, hasSet := cutTopLevelKeyword(part, "SET")
+			if ttlIdentifierRegexp.MatchString(key) {
+				rules[last].GroupBy = append(rules[last].GroupBy, key)
+				if hasSet {
+					inSet = true
+					addTTLAssignment(&rules[last], rest)
+				}
+				continue
+			}
+		}
+
+		rule := parseTTLRule(part)
+		inGroupBy = len(rule.GroupBy) > 0
+		inSet = rule.Set != nil
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func parseTTLRule(s string) ClickHouseTTLRule {
+	var rule ClickHouseTTLRule
+
+	s, set, hasSet := cutTopLevelKeyword(s, "SET")
+	s, groupBy, hasGroupBy := cutTopLevelKeyword(s, "GROUP BY")
+	s, where, _ := cutTopLevelKeyword(s, "WHERE")
+
+	if hasGroupBy {
+		rule.GroupBy = []string{groupBy}
+	}
+	if hasSet {
+		addTTLAssignment(&rule, set)
+	}
+	rule.Where = where
+
+	if expr, disk, ok := cutTopLevelKeyword(s, "TO DISK"); ok {
+		rule.Expression, rule.ToDisk = expr, unquoteValue(disk)
+	} else if expr, volume, ok := cutTopLevelKeyword(s, "TO VOLUME"); ok {
+		rule.Expression, rule.ToVolume = expr, unquoteValue(volume)
+	} else if expr, codec, ok := cutTopLevelKeyword(s, "RECOMPRESS"); ok {
+		codec = strings.TrimSpace(strings.TrimPrefix(codec, "CODEC"))
+		rule.Expression, rule.RecompressCodec = expr, strings.TrimSuffix(strings.TrimPrefix(codec, "("), ")")
+	} else if expr, _, ok := cutTopLevelKeyword(s, "DELETE"); ok {
+		rule.Expression = expr
+	} else {
+		rule.Expression = s
+	}
+	rule.Expression = strings.TrimSpace(rule.Expression)
+
+	return rule
+}
+
+func addTTLAssignment(rule *ClickHouseTTLRule, assignment string) {
+	if rule.Set == nil {
+		rule.Set = make(map[string]string)
+	}
+	if match := ttlAssignmentRegexp.FindStringSubmatch(strings.TrimSpace(assignment)); match != nil {
+		rule.Set[unquoteTicks(match[1])] = strings.TrimSpace(match[2])
+	}
+}
+
+// cutTopLevelKeyword slices s around the first top-level occurrence of the keyword.
+func cutTopLevelKeyword(s, keyword string) (before, after string, found bool) {
+	index := indexTopLevelKeyword(s, keyword)
+	if index == -1 {
+		return strings.TrimSpace(s), "", false
+	}
+	return strings.TrimSpace(s[:index]), strings.TrimSpace(s[index+len(keyword):]), true
+}
+
+func unquoteValue(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		s = s[1 : len(s)-1]
+		s = strings.ReplaceAll(s, `\'`, `'`)
+		s = strings.ReplaceAll(s, `\\`, `\`)
+	}
+	return s
+}
+
+// AlterTableTTL replaces TTL rules of the table. Existing data is processed according to the new rules
+// only if materialize is true, otherwise the rules apply to new parts and merges.
+func (client *ClickHouseClient) AlterTableTTL(ctx context.Context, db, table string, rules []ClickHouseTTLRule, materialize bool) error {
+	var query string
+	if len(rules) == 0 {
+		query = fmt.Sprintf(
+			"ALTER TABLE %s.%s%s REMOVE TTL",
+			QuoteID(db),
+			QuoteID(table),
+			client.onCluster(),
+		)
+	} else {
+		query = fmt.Sprintf(
+			"ALTER TABLE %s.%s%s MODIFY TTL %s",
+			QuoteID(db),
+			QuoteID(table),
+			client.onCluster(),
+			ttlQuery(rules),
+		)
+	}
+
+	tflog.Info(ctx, "Changing TTL of a table", dict{"query": query})
+
+	err := client.Conn.Exec(clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{
+		"materialize_ttl_after_modify": 0,
+	})), query)
+	if err != nil || !materialize || len(rules) == 0 {
+		return err
+	}
+
+	query = fmt.Sprintf(
+		"ALTER TABLE %s.%s%s MATERIALIZE TTL",
+		QuoteID(db),
+		QuoteID(table),
+		client.onCluster(),
+	)
+
+	tflog.Info(ctx, "Materializing TTL of a table", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
@@ -0,0 +1,175 @@
+package chclient
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+)
+
+func TestTTLRuleString(t *testing.T) {
+	testCases := []struct {
+		rule     ClickHouseTTLRule
+		expected string
+	}{
+		{
+			rule:     ClickHouseTTLRule{Expression: "d + INTERVAL 1 MONTH"},
+			expected: "d + INTERVAL 1 MONTH",
+		},
+		{
+			rule:     ClickHouseTTLRule{Expression: "d + INTERVAL 1 MONTH", Where: "status = 'done'"},
+			expected: "d + INTERVAL 1 MONTH WHERE status = 'done'",
+		},
+		{
+			rule:     ClickHouseTTLRule{Expression: "d + INTERVAL 1 WEEK", ToDisk: "cold"},
+			expected: "d + INTERVAL 1 WEEK TO DISK 'cold'",
+		},
+		{
+			rule:     ClickHouseTTLRule{Expression: "d + INTERVAL 1 WEEK", ToVolume: "slow"},
+			expected: "d + INTERVAL 1 WEEK TO VOLUME 'slow'",
+		},
+		{
+			rule:     ClickHouseTTLRule{Expression: "d + INTERVAL 1 DAY", RecompressCodec: "ZSTD(17)"},
+			expected: "d + INTERVAL 1 DAY RECOMPRESS CODEC(ZSTD(17))",
+		},
+		{
+			rule: ClickHouseTTLRule{
+				Expression: "d + INTERVAL 1 YEAR",
+				GroupBy:    []string{"k1", "k2"},
+				Set:        map[string]string{"y": "min(y)", "x": "max(x)"},
+			},
+			expected: "d + INTERVAL 1 YEAR GROUP BY k1, k2 SET x = max(x), y = min(y)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if actual := tc.rule.String(); actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseTableTTL(t *testing.T) {
+	testCases := []struct {
+		name       string
+		engineFull string
+		expected   []ClickHouseTTLRule
+	}{
+		{
+			name:       "No TTL",
+			engineFull: "MergeTree ORDER BY id SETTINGS index_granularity = 8192",
+		},
+		{
+			name:       "Delete rule",
+			engineFull: "MergeTree ORDER BY id TTL d + toIntervalMonth(1) SETTINGS index_granularity = 8192",
+			expected:   []ClickHouseTTLRule{{Expression: "d + toIntervalMonth(1)"}},
+		},
+		{
+			name:       "Several rules",
+			engineFull: "MergeTree ORDER BY id TTL d + toIntervalDay(1) RECOMPRESS CODEC(ZSTD(17)), d + toIntervalWeek(1) TO DISK 'cold', d + toIntervalMonth(1) TO VOLUME 'slow', d + toIntervalYear(1) WHERE status = 'done'",
+			expected: []ClickHouseTTLRule{
+				{Expression: "d + toIntervalDay(1)", RecompressCodec: "ZSTD(17)"},
+				{Expression: "d + toIntervalWeek(1)", ToDisk: "cold"},
+				{Expression: "d + toIntervalMonth(1)", ToVolume: "slow"},
+				{Expression: "d + toIntervalYear(1)", Where: "status = 'done'"},
+			},
+		},
+		{
+			name:       "Group by rule followed by another rule",
+			engineFull: "MergeTree ORDER BY (k1, k2, d) TTL d + toIntervalMonth(1) GROUP BY k1, k2 SET x = max(x), y = min(y), d + toIntervalYear(1) SETTINGS index_granularity = 8192",
+			expected: []ClickHouseTTLRule{
+				{
+					Expression: "d + toIntervalMonth(1)",
+					GroupBy:    []string{"k1", "k2"},
+					Set:        map[string]string{"x": "max(x)", "y": "min(y)"},
+				},
+				{Expression: "d + toIntervalYear(1)"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := parseTableTTL(tc.engineFull)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSameTTL(t *testing.T) {
+	configured := []ClickHouseTTLRule{
+		{Expression: "d + INTERVAL 1 DAY", RecompressCodec: "ZSTD"},
+		{Expression: "d + INTERVAL 1 MONTH", GroupBy: []string{"k"}, Set: map[string]string{"x": "max(x)"}},
+	}
+	actual := []ClickHouseTTLRule{
+		{Expression: "d + toIntervalDay(1)", RecompressCodec: "ZSTD(1)"},
+		{Expression: "d + toIntervalMonth(1)", GroupBy: []string{"k"}, Set: map[string]string{"x": "max(x)"}},
+	}
+
+	if !SameTTL(configured, actual) {
+		t.Error("expected TTL rules to be the same")
+	}
+	if SameTTL(configured, actual[:1]) {
+		t.Error("expected TTL rules of different length to differ")
+	}
+	if SameTTL(configured[:1], []ClickHouseTTLRule{{Expression: "d + toIntervalDay(1)", ToDisk: "cold"}}) {
+		t.Error("expected TTL rules with different targets to differ")
+	}
+}
+
+func TestAlterTableTTL(t *testing.T) {
+	rules := []ClickHouseTTLRule{{Expression: "d + INTERVAL 1 MONTH"}}
+
+	testCases := []struct {
+		name        string
+		rules       []ClickHouseTTLRule
+		materialize bool
+		expected    []string
+	}{
+		{
+			name:     "TTL is modified",
+			rules:    rules,
+			expected: []string{`ALTER TABLE "db"."table" MODIFY TTL d + INTERVAL 1 MONTH`},
+		},
+		{
+			name:        "TTL is modified and materialized",
+			rules:       rules,
+			materialize: true,
+			expected: []string{
+				`ALTER TABLE "db"."table" MODIFY TTL d + INTERVAL 1 MONTH`,
+				`ALTER TABLE "db"."table" MATERIALIZE TTL`,
+			},
+		},
+		{
+			name:        "TTL is removed",
+			materialize: true,
+			expected:    []string{`ALTER TABLE "db"."table" REMOVE TTL`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			conn := mock_driver.NewMockConn(mockCtrl)
+			var calls []*gomock.Call
+			for _, query := range tc.expected {
+				calls = append(calls, conn.EXPECT().Exec(gomock.Any(), query).Return(nil).Times(1))
+			}
+			gomock.InOrder(calls...)
+
+			client := ClickHouseClient{Conn: conn}
+			if err := client.AlterTableTTL(ctx, "db", "table", tc.rules, tc.materialize); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
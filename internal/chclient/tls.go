@@ -0,0 +1,86 @@
+package chclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLSOptions describes how to establish a TLS connection to ClickHouse.
+// Every certificate and key can be passed either as a path to a PEM file or as PEM-encoded content.
+type TLSOptions struct {
+	CAFile             string
+	CAPEM              string
+	CertFile           string
+	CertPEM            string
+	KeyFile            string
+	KeyPEM             string
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+// Config builds *tls.Config from TLSOptions. If no CA is given, system CA pool is used.
+func (opts TLSOptions) Config() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         opts.ServerName,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+
+	caPEM, err := readPEM(opts.CAFile, opts.CAPEM, "CA bundle")
+	if err != nil {
+		return nil, err
+	}
+	if caPEM != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("CA bundle does not contain any valid PEM-encoded certificate")
+		}
+		config.RootCAs = pool
+	}
+
+	certPEM, err := readPEM(opts.CertFile, opts.CertPEM, "client certificate")
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := readPEM(opts.KeyFile, opts.KeyPEM, "client key")
+	if err != nil {
+		return nil, err
+	}
+
+	if (certPEM == nil) != (keyPEM == nil) {
+		return nil, errors.New("client certificate and client key should be set together")
+	}
+	if certPEM != nil {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+func readPEM(file, content, what string) ([]byte, error) {
+	if file != "" && content != "" {
+		return nil, fmt.Errorf("%s should be set either as a file or as PEM content, not both", what)
+	}
+
+	if content != "" {
+		return []byte(content), nil
+	}
+
+	if file == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", what, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,248 @@
+package chclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM string
+	keyPEM  string
+}
+
+func newTestCert(t *testing.T, cn string, parent *testCert, hosts ...string) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+
+	signerCert, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+// startTLSServer starts a local TLS server that requires a client certificate signed by ca.
+func startTLSServer(t *testing.T, ca, server *testCert) string {
+	t.Helper()
+
+	serverCert, err := tls.X509KeyPair([]byte(server.certPEM), []byte(server.keyPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			if tlsConn, ok := conn.(*tls.Conn); ok {
+				_ = tlsConn.Handshake()
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func handshake(addr string, config *tls.Config) error {
+	conn, err := tls.Dial("tcp", addr, config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	// TLS 1.3 reports rejected client certificates only on the first read.
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() || errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+func TestTLSOptionsConfig(t *testing.T) {
+	ca := newTestCert(t, "test-ca", nil)
+	server := newTestCert(t, "clickhouse", ca, "clickhouse.local", "127.0.0.1")
+	client := newTestCert(t, "terraform", ca)
+	otherCA := newTestCert(t, "other-ca", nil)
+	addr := startTLSServer(t, ca, server)
+
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	caFile := writeFile("ca.pem", ca.certPEM)
+	certFile := writeFile("client.pem", client.certPEM)
+	keyFile := writeFile("client.key", client.keyPEM)
+
+	testCases := []struct {
+		name         string
+		opts         TLSOptions
+		handshakeErr bool
+	}{
+		{
+			name: "PEM content",
+			opts: TLSOptions{CAPEM: ca.certPEM, CertPEM: client.certPEM, KeyPEM: client.keyPEM},
+		},
+		{
+			name: "Files",
+			opts: TLSOptions{CAFile: caFile, CertFile: certFile, KeyFile: keyFile},
+		},
+		{
+			name: "Server name override",
+			opts: TLSOptions{CAFile: caFile, CertFile: certFile, KeyFile: keyFile, ServerName: "clickhouse.local"},
+		},
+		{
+			name:         "Wrong server name",
+			opts:         TLSOptions{CAFile: caFile, CertFile: certFile, KeyFile: keyFile, ServerName: "example.com"},
+			handshakeErr: true,
+		},
+		{
+			name:         "Unknown CA",
+			opts:         TLSOptions{CAPEM: otherCA.certPEM, CertPEM: client.certPEM, KeyPEM: client.keyPEM},
+			handshakeErr: true,
+		},
+		{
+			name: "Insecure skip verify",
+			opts: TLSOptions{CertPEM: client.certPEM, KeyPEM: client.keyPEM, InsecureSkipVerify: true},
+		},
+		{
+			name:         "No client certificate",
+			opts:         TLSOptions{CAPEM: ca.certPEM},
+			handshakeErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := tc.opts.Config()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err = handshake(addr, config)
+			if tc.handshakeErr && err == nil {
+				t.Errorf("expected handshake to fail")
+			}
+			if !tc.handshakeErr && err != nil {
+				t.Errorf("unexpected handshake error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTLSOptionsConfigErrors(t *testing.T) {
+	ca := newTestCert(t, "test-ca", nil)
+	client := newTestCert(t, "terraform", ca)
+
+	testCases := []struct {
+		name string
+		opts TLSOptions
+	}{
+		{
+			name: "Invalid CA",
+			opts: TLSOptions{CAPEM: "not a certificate"},
+		},
+		{
+			name: "Missing CA file",
+			opts: TLSOptions{CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+		},
+		{
+			name: "Both CA file and PEM",
+			opts: TLSOptions{CAFile: "ca.pem", CAPEM: ca.certPEM},
+		},
+		{
+			name: "Certificate without key",
+			opts: TLSOptions{CertPEM: client.certPEM},
+		},
+		{
+			name: "Mismatched key",
+			opts: TLSOptions{CertPEM: client.certPEM, KeyPEM: ca.keyPEM},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.opts.Config(); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
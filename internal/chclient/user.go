@@ -2,14 +2,18 @@ package chclient
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"strings"
 
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type ClickHouseUserAuthType interface {
+	// Method returns the authentication type as ClickHouse shows it in system.users.auth_type.
+	Method() string
 	getIdentifiedWithQuery() string
 }
 
@@ -18,6 +22,10 @@ type Sha256HashAuth struct {
 	Salt string
 }
 
+func (auth Sha256HashAuth) Method() string {
+	return "sha256_password"
+}
+
 func (auth Sha256HashAuth) getIdentifiedWithQuery() string {
 	result := "sha256_hash BY " + QuoteValue(auth.Hash)
 	if auth.Salt != "" {
@@ -30,10 +38,238 @@ type Sha256PasswordAuth struct {
 	Password string
 }
 
+func (auth Sha256PasswordAuth) Method() string {
+	return "sha256_password"
+}
+
 func (auth Sha256PasswordAuth) getIdentifiedWithQuery() string {
 	return "sha256_password BY " + QuoteValue(auth.Password)
 }
 
+type PlaintextPasswordAuth struct {
+	Password string
+}
+
+func (auth PlaintextPasswordAuth) Method() string {
+	return "plaintext_password"
+}
+
+func (auth PlaintextPasswordAuth) getIdentifiedWithQuery() string {
+	return "plaintext_password BY " + QuoteValue(auth.Password)
+}
+
+type DoubleSha1PasswordAuth struct {
+	Password string
+}
+
+func (auth DoubleSha1PasswordAuth) Method() string {
+	return "double_sha1_password"
+}
+
+func (auth DoubleSha1PasswordAuth) getIdentifiedWithQuery() string {
+	return "double_sha1_password BY " + QuoteValue(auth.Password)
+}
+
+type DoubleSha1HashAuth struct {
+	Hash string
+}
+
+func (auth DoubleSha1HashAuth) Method() string {
+	return "double_sha1_password"
+}
+
+func (auth DoubleSha1HashAuth) getIdentifiedWithQuery() string {
+	return "double_sha1_hash BY " + QuoteValue(auth.Hash)
+}
+
+type BcryptPasswordAuth struct {
+	Password string
+}
+
+func (auth BcryptPasswordAuth) Method() string {
+	return "bcrypt_password"
+}
+
+func (auth BcryptPasswordAuth) getIdentifiedWithQuery() string {
+	return "bcrypt_password BY " + QuoteValue(auth.Password)
+}
+
+type BcryptHashAuth struct {
+	Hash string
+}
+
+func (auth BcryptHashAuth) Method() string {
+	return "bcrypt_password"
+}
+
+func (auth BcryptHashAuth) getIdentifiedWithQuery() string {
+	return "bcrypt_hash BY " + QuoteValue(auth.Hash)
+}
+
+type NoPasswordAuth struct{}
+
+func (auth NoPasswordAuth) Method() string {
+	return "no_password"
+}
+
+func (auth NoPasswordAuth) getIdentifiedWithQuery() string {
+	return "no_password"
+}
+
+type LDAPAuth struct {
+	Server string
+}
+
+func (auth LDAPAuth) Method() string {
+	return "ldap"
+}
+
+func (auth LDAPAuth) getIdentifiedWithQuery() string {
+	return "ldap SERVER " + QuoteValue(auth.Server)
+}
+
+type KerberosAuth struct {
+	Realm string
+}
+
+func (auth KerberosAuth) Method() string {
+	return "kerberos"
+}
+
+func (auth KerberosAuth) getIdentifiedWithQuery() string {
+	if auth.Realm == "" {
+		return "kerberos"
+	}
+	return "kerberos REALM " + QuoteValue(auth.Realm)
+}
+
+// SSLCertificateAuth identifies users by either common names or subject alternative names of their certificates.
+type SSLCertificateAuth struct {
+	CommonNames     []string
+	SubjectAltNames []string
+}
+
+func (auth SSLCertificateAuth) Method() string {
+	return "ssl_certificate"
+}
+
+func (auth SSLCertificateAuth) getIdentifiedWithQuery() string {
+	if len(auth.SubjectAltNames) > 0 {
+		return "ssl_certificate SAN " + strings.Join(QuoteList(auth.SubjectAltNames, "'"), ", ")
+	}
+	return "ssl_certificate CN " + strings.Join(QuoteList(auth.CommonNames, "'"), ", ")
+}
+
+type SSHKey struct {
+	Key  string
+	Type string
+}
+
+type SSHKeyAuth struct {
+	Keys []SSHKey
+}
+
+func (auth SSHKeyAuth) Method() string {
+	return "ssh_key"
+}
+
+func (auth SSHKeyAuth) getIdentifiedWithQuery() string {
+	keys := make([]string, 0, len(auth.Keys))
+	for _, key := range auth.Keys {
+		keys = append(keys, fmt.Sprintf("KEY %s TYPE %s", QuoteValue(key.Key), QuoteValue(key.Type)))
+	}
+	return "ssh_key BY " + strings.Join(keys, ", ")
+}
+
+type HTTPAuth struct {
+	Server string
+	Scheme string
+}
+
+func (auth HTTPAuth) Method() string {
+	return "http"
+}
+
+func (auth HTTPAuth) getIdentifiedWithQuery() string {
+	result := "http SERVER " + QuoteValue(auth.Server)
+	if auth.Scheme != "" {
+		result += " SCHEME " + QuoteValue(auth.Scheme)
+	}
+	return result
+}
+
+// UnsupportedAuth is an authentication method the provider cannot manage.
+// It is only returned when reading users created outside Terraform.
+type UnsupportedAuth struct {
+	Type   string
+	Params map[string]any
+}
+
+func (auth UnsupportedAuth) Method() string {
+	return auth.Type
+}
+
+func (auth UnsupportedAuth) getIdentifiedWithQuery() string {
+	return auth.Type
+}
+
+// authFromServer converts auth_type and auth_params columns of system.users into a typed auth.
+// Secrets are never exposed, so only the method and non-secret parameters are filled.
+func authFromServer(authType string, authParams string) (ClickHouseUserAuthType, error) {
+	params := map[string]any{}
+	if authParams != "" {
+		if err := json.Unmarshal([]byte(authParams), &params); err != nil {
+			return nil, fmt.Errorf("cannot parse auth_params %q: %w", authParams, err)
+		}
+	}
+
+	stringParam := func(name string) string {
+		value, _ := params[name].(string)
+		return value
+	}
+	listParam := func(name string) []string {
+		values, _ := params[name].([]any)
+		result := make([]string, 0, len(values))
+		for _, v := range values {
+			if value, ok := v.(string); ok {
+				result = append(result, value)
+			}
+		}
+		return result
+	}
+
+	switch authType {
+	case "sha256_password":
+		if salt, ok := params["salt"].(string); ok {
+			return Sha256HashAuth{Salt: salt}, nil
+		}
+		return Sha256PasswordAuth{}, nil
+	case "plaintext_password":
+		return PlaintextPasswordAuth{}, nil
+	case "double_sha1_password":
+		return DoubleSha1PasswordAuth{}, nil
+	case "bcrypt_password":
+		return BcryptPasswordAuth{}, nil
+	case "no_password":
+		return NoPasswordAuth{}, nil
+	case "ldap":
+		return LDAPAuth{Server: stringParam("server")}, nil
+	case "kerberos":
+		return KerberosAuth{Realm: stringParam("realm")}, nil
+	case "ssl_certificate":
+		return SSLCertificateAuth{
+			CommonNames:     listParam("common_names"),
+			SubjectAltNames: listParam("subject_alt_names"),
+		}, nil
+	case "ssh_key":
+		return SSHKeyAuth{}, nil
+	case "http":
+		return HTTPAuth{Server: stringParam("server"), Scheme: stringParam("scheme")}, nil
+	default:
+		return UnsupportedAuth{Type: authType, Params: params}, nil
+	}
+}
+
 type ClickHouseUserHosts struct {
 	Ip     []net.IPNet
 	Name   []string
@@ -83,17 +319,27 @@ func (db DefaultDatabase) String() string {
 }
 
 type ClickHouseUser struct {
-	Name            string
-	Auth            ClickHouseUserAuthType
+	Name string
+	// Auth lists authentication methods of the user. Any of them can be used to log in.
+	Auth            []ClickHouseUserAuthType
 	Hosts           *ClickHouseUserHosts
 	DefaultDatabase DefaultDatabase
 }
 
+func getIdentifiedWithQuery(auth []ClickHouseUserAuthType) string {
+	methods := make([]string, 0, len(auth))
+	for _, a := range auth {
+		methods = append(methods, a.getIdentifiedWithQuery())
+	}
+	return strings.Join(methods, ", ")
+}
+
 func (client *ClickHouseClient) CreateUser(ctx context.Context, user ClickHouseUser) error {
 	query := fmt.Sprintf(
-		"CREATE USER %s IDENTIFIED WITH %s HOST %s DEFAULT DATABASE %s",
+		"CREATE USER %s%s IDENTIFIED WITH %s HOST %s DEFAULT DATABASE %s",
 		user.Name,
-		user.Auth.getIdentifiedWithQuery(),
+		client.onCluster(),
+		getIdentifiedWithQuery(user.Auth),
 		user.Hosts.GetHostsQuery(),
 		user.DefaultDatabase.String(),
 	)
@@ -105,8 +351,9 @@ func (client *ClickHouseClient) CreateUser(ctx context.Context, user ClickHouseU
 
 func (client *ClickHouseClient) DropUser(ctx context.Context, user string) error {
 	query := fmt.Sprintf(
-		"DROP USER %s",
+		"DROP USER %s%s",
 		QuoteValue(user),
+		client.onCluster(),
 	)
 
 	tflog.Info(ctx, "Dropping a user", dict{"query": query})
@@ -114,12 +361,56 @@ func (client *ClickHouseClient) DropUser(ctx context.Context, user string) error
 	return client.Conn.Exec(ctx, query)
 }
 
+// userAuthColumns returns expressions that select auth_type and auth_params of system.users as arrays.
+// Servers with multiple authentication methods per user store them as arrays, older ones as scalars.
+// If the server version is unknown, the type of auth_type column is checked.
+func (client *ClickHouseClient) userAuthColumns(ctx context.Context) (string, string, error) {
+	if client.ServerVersion != (proto.Version{}) {
+		authType, authParams := authColumnExpressions(client.Supports(CapabilityMultipleAuthMethods))
+		return authType, authParams, nil
+	}
+
+	query := `SELECT "type" FROM "system"."columns"
+WHERE "database" = 'system' AND "table" = 'users' AND "name" = 'auth_type'`
+
+	rows, err := client.Conn.Query(ctx, query)
+	if err != nil {
+		return "", "", err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var columnType string
+	if rows.Next() {
+		if err = rows.Scan(&columnType); err != nil {
+			return "", "", err
+		}
+	}
+
+	authType, authParams := authColumnExpressions(strings.HasPrefix(columnType, "Array("))
+	return authType, authParams, nil
+}
+
+func authColumnExpressions(arrays bool) (string, string) {
+	if arrays {
+		return `arrayMap(x -> toString(x), "auth_type")`, `"auth_params"`
+	}
+
+	return `[toString("auth_type")]`, `["auth_params"]`
+}
+
 func (client *ClickHouseClient) GetUser(ctx context.Context, name string) (ClickHouseUser, error) {
+	authTypeColumn, authParamsColumn, err := client.userAuthColumns(ctx)
+	if err != nil {
+		return ClickHouseUser{}, err
+	}
+
 	query := fmt.Sprintf(
-		`SELECT "name", "auth_type", "host_ip", "host_names",
+		`SELECT "name", %s, %s, "host_ip", "host_names",
 "host_names_regexp", "host_names_like", "default_database"
 FROM "system"."users"
 WHERE "name" = %s`,
+		authTypeColumn,
+		authParamsColumn,
 		QuoteValue(name),
 	)
 
@@ -129,20 +420,23 @@ WHERE "name" = %s`,
 	if err != nil {
 		return ClickHouseUser{}, err
 	}
+	defer func() { _ = rows.Close() }()
 
 	if !rows.Next() {
 		return ClickHouseUser{}, &NotFoundError{Entity: "user", Name: name, Query: query}
 	}
 
 	var nameReceived string
-	var authType string
+	var authTypes []string
+	var authParams []string
 	var chUserHosts = &ClickHouseUserHosts{}
 	var chUserHostsIp []string
 	var defaultDb string
 
 	err = rows.Scan(
 		&nameReceived,
-		&authType,
+		&authTypes,
+		&authParams,
 		&chUserHostsIp,
 		&chUserHosts.Name,
 		&chUserHosts.Regexp,
@@ -165,6 +459,27 @@ WHERE "name" = %s`,
 		chUserHosts.Ip = append(chUserHosts.Ip, *ipNet)
 	}
 
+	err = client.checkOnAllReplicas(ctx, "user", name, "users", `"name" = `+QuoteValue(name))
+	if err != nil {
+		return ClickHouseUser{}, err
+	}
+
+	auth := make([]ClickHouseUserAuthType, 0, len(authTypes))
+	for i, authType := range authTypes {
+		params := ""
+		if i < len(authParams) {
+			params = authParams[i]
+		}
+		a, err := authFromServer(authType, params)
+		if err != nil {
+			return ClickHouseUser{}, err
+		}
+		auth = append(auth, a)
+	}
+	if len(auth) == 0 {
+		auth = append(auth, NoPasswordAuth{})
+	}
+
 	if len(chUserHosts.Name) == 0 &&
 		len(chUserHosts.Like) == 0 &&
 		len(chUserHosts.Regexp) == 0 &&
@@ -177,12 +492,13 @@ WHERE "name" = %s`,
 
 	return ClickHouseUser{
 		Name:            nameReceived,
-		Auth:            Sha256PasswordAuth{},
+		Auth:            auth,
 		Hosts:           chUserHosts,
 		DefaultDatabase: DefaultDatabase(defaultDb),
 	}, nil
 }
 
+// AlterUser changes the user. Authentication methods are kept as is if user.Auth is empty.
 func (client *ClickHouseClient) AlterUser(ctx context.Context, origName string, user ClickHouseUser) error {
 	shouldRaname := origName != user.Name
 	var renameQuery string
@@ -190,11 +506,17 @@ func (client *ClickHouseClient) AlterUser(ctx context.Context, origName string,
 		renameQuery = "RENAME TO " + QuoteValue(user.Name)
 	}
 
+	var identifiedWithQuery string
+	if len(user.Auth) > 0 {
+		identifiedWithQuery = " IDENTIFIED WITH " + getIdentifiedWithQuery(user.Auth)
+	}
+
 	query := fmt.Sprintf(
-		"ALTER USER %s %s IDENTIFIED WITH %s HOST %s DEFAULT DATABASE %s",
+		"ALTER USER %s %s%s%s HOST %s DEFAULT DATABASE %s",
 		origName,
 		renameQuery,
-		user.Auth.getIdentifiedWithQuery(),
+		client.onCluster(),
+		identifiedWithQuery,
 		user.Hosts.GetHostsQuery(),
 		user.DefaultDatabase.String(),
 	)
@@ -208,3 +530,18 @@ func (client *ClickHouseClient) AlterUser(ctx context.Context, origName string,
 
 	return client.Conn.Exec(ctx, query)
 }
+
+// AddUserAuth adds authentication methods to the user keeping the existing ones,
+// so clients may keep using old credentials while new ones are rolled out.
+func (client *ClickHouseClient) AddUserAuth(ctx context.Context, name string, auth []ClickHouseUserAuthType) error {
+	query := fmt.Sprintf(
+		"ALTER USER %s%s ADD IDENTIFIED WITH %s",
+		name,
+		client.onCluster(),
+		getIdentifiedWithQuery(auth),
+	)
+
+	tflog.Info(ctx, "Adding authentication methods to a user", dict{"query": query})
+
+	return client.Conn.Exec(ctx, query)
+}
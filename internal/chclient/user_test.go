@@ -2,22 +2,33 @@ package chclient
 
 import (
 	"context"
+	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/vegassor/terraform-provider-clickhouse/internal/mock"
+	"reflect"
 	"testing"
 )
 
+const userAuthColumnsQuery = `SELECT "type" FROM "system"."columns"
+WHERE "database" = 'system' AND "table" = 'users' AND "name" = 'auth_type'`
+
 func TestGetUserSQL(t *testing.T) {
 	ctx := context.Background()
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
 	conn := mock_driver.NewMockConn(mockCtrl)
+	columns := mock_driver.NewMockRows(mockCtrl)
+	columns.EXPECT().Next().Return(false).Times(1)
+	columns.EXPECT().Close().Return(nil).Times(1)
+	conn.EXPECT().Query(ctx, userAuthColumnsQuery).Return(columns, nil).Times(1)
+
 	rows := mock_driver.NewMockRows(mockCtrl)
 	rows.EXPECT().Next().Return(true).Times(1)
 	rows.EXPECT().Scan(gomock.Any()).Return(nil).Times(1)
+	rows.EXPECT().Close().Return(nil).Times(1)
 
-	expectedQuery := `SELECT "name", "auth_type", "host_ip", "host_names",
+	expectedQuery := `SELECT "name", [toString("auth_type")], ["auth_params"], "host_ip", "host_names",
 "host_names_regexp", "host_names_like", "default_database"
 FROM "system"."users"
 WHERE "name" = 'my_user'`
@@ -26,3 +37,195 @@ WHERE "name" = 'my_user'`
 	client := ClickHouseClient{Conn: conn}
 	_, _ = client.GetUser(ctx, "my_user")
 }
+
+func TestGetUserAuth(t *testing.T) {
+	testCases := []struct {
+		name       string
+		columnType string
+		authTypes  []string
+		authParams []string
+		expected   []ClickHouseUserAuthType
+	}{
+		{
+			name:       "Scalar sha256_password",
+			columnType: "Enum8('no_password' = 0, 'plaintext_password' = 1, 'sha256_password' = 2)",
+			authTypes:  []string{"sha256_password"},
+			authParams: []string{"{}"},
+			expected:   []ClickHouseUserAuthType{Sha256PasswordAuth{}},
+		},
+		{
+			name:       "Salt is exposed",
+			columnType: "Array(Enum8('no_password' = 0, 'plaintext_password' = 1, 'sha256_password' = 2))",
+			authTypes:  []string{"sha256_password"},
+			authParams: []string{`{"salt":"abc"}`},
+			expected:   []ClickHouseUserAuthType{Sha256HashAuth{Salt: "abc"}},
+		},
+		{
+			name:       "Plaintext password",
+			columnType: "Enum8('no_password' = 0, 'plaintext_password' = 1, 'sha256_password' = 2)",
+			authTypes:  []string{"plaintext_password"},
+			authParams: []string{""},
+			expected:   []ClickHouseUserAuthType{PlaintextPasswordAuth{}},
+		},
+		{
+			name:       "LDAP server",
+			columnType: "Enum8('no_password' = 0, 'plaintext_password' = 1, 'ldap' = 5)",
+			authTypes:  []string{"ldap"},
+			authParams: []string{`{"server":"my_ldap"}`},
+			expected:   []ClickHouseUserAuthType{LDAPAuth{Server: "my_ldap"}},
+		},
+		{
+			name:       "SSL certificate common names",
+			columnType: "Array(Enum8('no_password' = 0, 'ssl_certificate' = 7))",
+			authTypes:  []string{"ssl_certificate"},
+			authParams: []string{`{"common_names":["host.example.com"]}`},
+			expected:   []ClickHouseUserAuthType{SSLCertificateAuth{CommonNames: []string{"host.example.com"}, SubjectAltNames: []string{}}},
+		},
+		{
+			name:       "Multiple methods",
+			columnType: "Array(Enum8('no_password' = 0, 'plaintext_password' = 1, 'sha256_password' = 2))",
+			authTypes:  []string{"sha256_password", "plaintext_password"},
+			authParams: []string{`{"salt":"abc"}`, "{}"},
+			expected:   []ClickHouseUserAuthType{Sha256HashAuth{Salt: "abc"}, PlaintextPasswordAuth{}},
+		},
+		{
+			name:       "Method not managed by the provider",
+			columnType: "Array(Enum8('no_password' = 0, 'jwt' = 11))",
+			authTypes:  []string{"jwt"},
+			authParams: []string{"{}"},
+			expected:   []ClickHouseUserAuthType{UnsupportedAuth{Type: "jwt", Params: map[string]any{}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			conn := mock_driver.NewMockConn(mockCtrl)
+			columns := mock_driver.NewMockRows(mockCtrl)
+			columns.EXPECT().Next().Return(true).Times(1)
+			columns.EXPECT().Close().Return(nil).Times(1)
+			columns.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+				columnType, ok := dest[0].(*string)
+				if !ok {
+					return errors.New("unexpected scan destination")
+				}
+				*columnType = tc.columnType
+				return nil
+			}).Times(1)
+			conn.EXPECT().Query(ctx, userAuthColumnsQuery).Return(columns, nil).Times(1)
+
+			rows := mock_driver.NewMockRows(mockCtrl)
+			rows.EXPECT().Next().Return(true).Times(1)
+			rows.EXPECT().Close().Return(nil).Times(1)
+			rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+				name, ok1 := dest[0].(*string)
+				authTypes, ok2 := dest[1].(*[]string)
+				authParams, ok3 := dest[2].(*[]string)
+				if !ok1 || !ok2 || !ok3 {
+					return errors.New("unexpected scan destination")
+				}
+				*name, *authTypes, *authParams = "my_user", tc.authTypes, tc.authParams
+				return nil
+			}).Times(1)
+			conn.EXPECT().Query(ctx, gomock.Any()).Return(rows, nil).Times(1)
+
+			client := ClickHouseClient{Conn: conn}
+			user, err := client.GetUser(ctx, "my_user")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(user.Auth, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, user.Auth)
+			}
+		})
+	}
+}
+
+func TestIdentifiedWithQuery(t *testing.T) {
+	testCases := []struct {
+		auth     ClickHouseUserAuthType
+		expected string
+	}{
+		{Sha256HashAuth{Hash: "abc", Salt: "salt"}, `sha256_hash BY 'abc' SALT 'salt'`},
+		{Sha256PasswordAuth{Password: "qwerty"}, `sha256_password BY 'qwerty'`},
+		{PlaintextPasswordAuth{Password: "qwerty"}, `plaintext_password BY 'qwerty'`},
+		{DoubleSha1PasswordAuth{Password: "qwerty"}, `double_sha1_password BY 'qwerty'`},
+		{DoubleSha1HashAuth{Hash: "abc"}, `double_sha1_hash BY 'abc'`},
+		{BcryptPasswordAuth{Password: "qwerty"}, `bcrypt_password BY 'qwerty'`},
+		{BcryptHashAuth{Hash: "$2a$12$abc"}, `bcrypt_hash BY '$2a$12$abc'`},
+		{NoPasswordAuth{}, `no_password`},
+		{LDAPAuth{Server: "my_ldap"}, `ldap SERVER 'my_ldap'`},
+		{KerberosAuth{}, `kerberos`},
+		{KerberosAuth{Realm: "EXAMPLE.COM"}, `kerberos REALM 'EXAMPLE.COM'`},
+		{SSLCertificateAuth{CommonNames: []string{"a", "b"}}, `ssl_certificate CN 'a', 'b'`},
+		{SSLCertificateAuth{SubjectAltNames: []string{"URI:spiffe://foo"}}, `ssl_certificate SAN 'URI:spiffe://foo'`},
+		{
+			SSHKeyAuth{Keys: []SSHKey{{Key: "AAAA", Type: "ssh-ed25519"}, {Key: "BBBB", Type: "ssh-rsa"}}},
+			`ssh_key BY KEY 'AAAA' TYPE 'ssh-ed25519', KEY 'BBBB' TYPE 'ssh-rsa'`,
+		},
+		{HTTPAuth{Server: "auth", Scheme: "basic"}, `http SERVER 'auth' SCHEME 'basic'`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if actual := tc.auth.getIdentifiedWithQuery(); actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAlterUserSQL(t *testing.T) {
+	auth := []ClickHouseUserAuthType{
+		Sha256PasswordAuth{Password: "old"},
+		Sha256PasswordAuth{Password: "new"},
+	}
+
+	testCases := []struct {
+		name     string
+		alter    func(ctx context.Context, client ClickHouseClient) error
+		expected string
+	}{
+		{
+			name: "Replace authentication methods",
+			alter: func(ctx context.Context, client ClickHouseClient) error {
+				return client.AlterUser(ctx, "my_user", ClickHouseUser{Name: "my_user", Auth: auth})
+			},
+			expected: "ALTER USER my_user  IDENTIFIED WITH sha256_password BY 'old', sha256_password BY 'new' " +
+				"HOST ANY DEFAULT DATABASE NONE",
+		},
+		{
+			name: "Keep authentication methods",
+			alter: func(ctx context.Context, client ClickHouseClient) error {
+				return client.AlterUser(ctx, "my_user", ClickHouseUser{Name: "my_user"})
+			},
+			expected: "ALTER USER my_user  HOST ANY DEFAULT DATABASE NONE",
+		},
+		{
+			name: "Add authentication methods",
+			alter: func(ctx context.Context, client ClickHouseClient) error {
+				return client.AddUserAuth(ctx, "my_user", auth[1:])
+			},
+			expected: "ALTER USER my_user ADD IDENTIFIED WITH sha256_password BY 'new'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			conn := mock_driver.NewMockConn(mockCtrl)
+			conn.EXPECT().Exec(ctx, tc.expected).Return(nil).Times(1)
+
+			if err := tc.alter(ctx, ClickHouseClient{Conn: conn}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
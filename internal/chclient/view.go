@@ -19,10 +19,11 @@ func (client *ClickHouseClient) CreateView(ctx context.Context, view ClickHouseV
 	}
 
 	query := fmt.Sprintf(
-		"CREATE %s VIEW %s.%s AS %s",
+		"CREATE %s VIEW %s.%s%s AS %s",
 		replacePart,
 		QuoteID(view.Database),
 		QuoteID(view.Name),
+		client.onCluster(),
 		view.Query,
 	)
 
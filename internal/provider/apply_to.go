@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+type ApplyToModel struct {
+	All    bool     `tfsdk:"all"`
+	Names  []string `tfsdk:"names"`
+	Except []string `tfsdk:"except"`
+}
+
+// applyToAttribute describes `TO` clause shared by settings profiles, quotas and row policies.
+func applyToAttribute(entity string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Users and roles the " + entity + " is applied to (`TO` clause). " +
+			"If not set, the " + entity + " is not applied to anybody",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"all": schema.BoolAttribute{
+				MarkdownDescription: "Apply to all users and roles",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"names": schema.SetAttribute{
+				MarkdownDescription: "Names of users and roles. Cannot be used with `all`",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("all")),
+				},
+			},
+			"except": schema.SetAttribute{
+				MarkdownDescription: "Names of users and roles to exclude (`EXCEPT` clause)",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators:          []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+		},
+	}
+}
+
+func (model *ApplyToModel) toChClientApplyTo() *chclient.ClickHouseApplyTo {
+	if model == nil {
+		return nil
+	}
+	return &chclient.ClickHouseApplyTo{All: model.All, Names: model.Names, Except: model.Except}
+}
+
+func fromChClientApplyTo(to *chclient.ClickHouseApplyTo) *ApplyToModel {
+	if to == nil {
+		return nil
+	}
+
+	model := &ApplyToModel{All: to.All}
+	if len(to.Names) > 0 {
+		model.Names = to.Names
+	}
+	if len(to.Except) > 0 {
+		model.Except = to.Except
+	}
+	return model
+}
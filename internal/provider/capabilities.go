@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+// requireCapability adds an error to the attribute if the ClickHouse server does not support the capability,
+// so that the plan fails instead of a query during apply. Nothing is checked before the provider is configured.
+func requireCapability(diags *diag.Diagnostics, client *chclient.ClickHouseClient, capability chclient.Capability, attribute path.Path) {
+	if client == nil {
+		return
+	}
+	if err := client.RequireCapability(capability); err != nil {
+		diags.AddAttributeError(attribute, "Feature is not supported by ClickHouse server", err.Error())
+	}
+}
+
+// elementsWithValue returns indexes of objects in the list whose string attribute is known and equal to the value.
+func elementsWithValue(list types.List, attribute, value string) []int {
+	var indexes []int
+	for i, element := range list.Elements() {
+		object, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+		actual, ok := object.Attributes()[attribute].(types.String)
+		if ok && !actual.IsUnknown() && actual.ValueString() == value {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
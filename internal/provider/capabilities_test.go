@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+func TestElementsWithValue(t *testing.T) {
+	objectType := map[string]attr.Type{"type": types.StringType}
+	list := types.ListValueMust(types.ObjectType{AttrTypes: objectType}, []attr.Value{
+		types.ObjectValueMust(objectType, map[string]attr.Value{"type": types.StringValue("CHECK")}),
+		types.ObjectValueMust(objectType, map[string]attr.Value{"type": types.StringValue("ASSUME")}),
+		types.ObjectValueMust(objectType, map[string]attr.Value{"type": types.StringUnknown()}),
+		types.ObjectValueMust(objectType, map[string]attr.Value{"type": types.StringValue("ASSUME")}),
+	})
+
+	expected := []int{1, 3}
+	if actual := elementsWithValue(list, "type", "ASSUME"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+	if actual := elementsWithValue(types.ListNull(types.ObjectType{AttrTypes: objectType}), "type", "ASSUME"); actual != nil {
+		t.Errorf("expected no elements, got %v", actual)
+	}
+}
+
+func TestRequireCapability(t *testing.T) {
+	attribute := path.Root("constraints").AtListIndex(0).AtName("type")
+
+	var diags diag.Diagnostics
+	requireCapability(&diags, nil, chclient.CapabilityAssumeConstraints, attribute)
+	requireCapability(&diags, &chclient.ClickHouseClient{}, chclient.CapabilityAssumeConstraints, attribute)
+	if diags.HasError() {
+		t.Errorf("expected no errors, got %v", diags)
+	}
+
+	client := &chclient.ClickHouseClient{ServerVersion: proto.Version{Major: 21, Minor: 8}}
+	requireCapability(&diags, client, chclient.CapabilityAssumeConstraints, attribute)
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("expected one error, got %v", diags)
+	}
+}
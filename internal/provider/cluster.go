@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+// clusterAttribute is a per-resource override of the provider-level `cluster` attribute.
+func clusterAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Name of a cluster to run DDL queries on (`ON CLUSTER` clause). " +
+			"Overrides `cluster` attribute of the provider",
+		Optional:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+}
+
+// clusterClient returns the client that runs queries on the cluster of a resource.
+// If the resource does not set the cluster, the cluster of the provider is used.
+func clusterClient(client *chclient.ClickHouseClient, cluster types.String) *chclient.ClickHouseClient {
+	return client.WithCluster(cluster.ValueString())
+}
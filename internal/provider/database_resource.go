@@ -33,6 +33,7 @@ type DatabaseResourceModel struct {
 	Name    types.String `tfsdk:"name"`
 	Engine  types.String `tfsdk:"engine"`
 	Comment types.String `tfsdk:"comment"`
+	Cluster types.String `tfsdk:"cluster"`
 	//	TODO: Add database UUID?
 }
 
@@ -73,6 +74,7 @@ func (r *DatabaseResource) Schema(ctx context.Context, req resource.SchemaReques
 				Default:             stringdefault.StaticString(""),
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
+			"cluster": clusterAttribute(),
 			// TODO: engine args and settings
 		},
 	}
@@ -94,7 +96,7 @@ func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateReques
 	}
 	data.ID = types.StringValue(data.Name.ValueString())
 
-	err := r.client.CreateDatabase(
+	err := clusterClient(r.client, data.Cluster).CreateDatabase(
 		ctx,
 		chclient.ClickHouseDatabase{
 			Name:    data.Name.ValueString(),
@@ -104,10 +106,7 @@ func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateReques
 	)
 
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot create database",
-			"Create database query failed: "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot create database", err)
 		return
 	}
 
@@ -124,7 +123,7 @@ func (r *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	receivedDb, err := r.client.GetDatabase(ctx, db.Name.ValueString())
+	receivedDb, err := clusterClient(r.client, db.Cluster).GetDatabase(ctx, db.Name.ValueString())
 	if err != nil {
 		handleNotFoundError(ctx, err, resp, "database", db.Name.ValueString())
 		return
@@ -150,12 +149,9 @@ func (r *DatabaseResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.DropDatabase(ctx, data.Name.ValueString())
+	err := clusterClient(r.client, data.Cluster).DropDatabase(ctx, data.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot delete database",
-			"Cannot drop database "+data.Name.ValueString()+": "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot delete database "+data.Name.ValueString(), err)
 		return
 	}
 }
@@ -0,0 +1,612 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+var _ resource.Resource = &DictionaryResource{}
+var _ resource.ResourceWithImportState = &DictionaryResource{}
+
+func NewDictionaryResource() resource.Resource {
+	return &DictionaryResource{}
+}
+
+type DictionaryResource struct {
+	client *chclient.ClickHouseClient
+}
+
+type DictionaryAttributeModel struct {
+	Name         string       `tfsdk:"name"`
+	Type         string       `tfsdk:"type"`
+	Default      types.String `tfsdk:"default"`
+	Expression   types.String `tfsdk:"expression"`
+	Hierarchical bool         `tfsdk:"hierarchical"`
+	Injective    bool         `tfsdk:"injective"`
+}
+
+type DictionaryClickHouseSourceModel struct {
+	Host            types.String `tfsdk:"host"`
+	Port            types.Int64  `tfsdk:"port"`
+	User            types.String `tfsdk:"user"`
+	Password        types.String `tfsdk:"password"`
+	DB              types.String `tfsdk:"db"`
+	Table           types.String `tfsdk:"table"`
+	Query           types.String `tfsdk:"query"`
+	Where           types.String `tfsdk:"where"`
+	InvalidateQuery types.String `tfsdk:"invalidate_query"`
+	UpdateField     types.String `tfsdk:"update_field"`
+	UpdateLag       types.Int64  `tfsdk:"update_lag"`
+	Secure          types.Bool   `tfsdk:"secure"`
+}
+
+type DictionaryFileSourceModel struct {
+	Path   string `tfsdk:"path"`
+	Format string `tfsdk:"format"`
+}
+
+type DictionaryExecutableSourceModel struct {
+	Command     string     `tfsdk:"command"`
+	Format      string     `tfsdk:"format"`
+	ImplicitKey types.Bool `tfsdk:"implicit_key"`
+}
+
+type DictionaryHTTPSourceModel struct {
+	URL      string            `tfsdk:"url"`
+	Format   string            `tfsdk:"format"`
+	User     types.String      `tfsdk:"user"`
+	Password types.String      `tfsdk:"password"`
+	Headers  map[string]string `tfsdk:"headers"`
+}
+
+type DictionarySourceModel struct {
+	ClickHouse *DictionaryClickHouseSourceModel `tfsdk:"clickhouse"`
+	File       *DictionaryFileSourceModel       `tfsdk:"file"`
+	Executable *DictionaryExecutableSourceModel `tfsdk:"executable"`
+	HTTP       *DictionaryHTTPSourceModel       `tfsdk:"http"`
+}
+
+type DictionaryRangeModel struct {
+	Min string `tfsdk:"min"`
+	Max string `tfsdk:"max"`
+}
+
+type DictionaryResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Database string       `tfsdk:"database"`
+	Name     string       `tfsdk:"name"`
+	FullName types.String `tfsdk:"full_name"`
+
+	PrimaryKey       []string                   `tfsdk:"primary_key"`
+	Attributes       []DictionaryAttributeModel `tfsdk:"attributes"`
+	Source           *DictionarySourceModel     `tfsdk:"source"`
+	Layout           string                     `tfsdk:"layout"`
+	LayoutParameters map[string]string          `tfsdk:"layout_parameters"`
+	LifetimeMin      int64                      `tfsdk:"lifetime_min"`
+	LifetimeMax      int64                      `tfsdk:"lifetime_max"`
+	Range            *DictionaryRangeModel      `tfsdk:"range"`
+
+	Comment string       `tfsdk:"comment"`
+	Cluster types.String `tfsdk:"cluster"`
+}
+
+func (r *DictionaryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dictionary"
+}
+
+func (r *DictionaryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	sourcePaths := []path.Expression{
+		path.MatchRelative().AtParent().AtName("clickhouse"),
+		path.MatchRelative().AtParent().AtName("file"),
+		path.MatchRelative().AtParent().AtName("executable"),
+		path.MatchRelative().AtParent().AtName("http"),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "ClickHouse dictionary. Changes of everything except database and name " +
+			"are applied with `CREATE OR REPLACE DICTIONARY`. " +
+			"See: https://clickhouse.com/docs/en/sql-reference/statements/create/dictionary",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{NewCompositePlanModifierFromStr([]string{"database", "name"}, ".")},
+			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "ClickHouse database name",
+				Required:            true,
+				Validators:          []validator.String{clickHouseIdentifierValidator},
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "ClickHouse dictionary name",
+				Required:            true,
+				Validators:          []validator.String{clickHouseIdentifierValidator},
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"full_name": schema.StringAttribute{
+				MarkdownDescription: "ClickHouse dictionary name in `database.dictionary` format",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{NewCompositePlanModifierFromStr([]string{"database", "name"}, ".")},
+			},
+			"primary_key": schema.ListAttribute{
+				MarkdownDescription: "Key attributes of the dictionary (`PRIMARY KEY` clause). " +
+					"More than one key requires one of `COMPLEX_KEY_*` layouts",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+			},
+			"attributes": schema.ListNestedAttribute{
+				MarkdownDescription: "Structure of the dictionary. Key and range attributes should be listed here as well",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Attribute name",
+							Required:            true,
+							Validators:          []validator.String{clickHouseIdentifierValidator},
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Attribute type. See: https://clickhouse.com/docs/en/sql-reference/data-types",
+							Required:            true,
+						},
+						"default": schema.StringAttribute{
+							MarkdownDescription: "Expression for the default value (`DEFAULT` clause)",
+							Optional:            true,
+						},
+						"expression": schema.StringAttribute{
+							MarkdownDescription: "Expression that ClickHouse executes on the source to get the value (`EXPRESSION` clause)",
+							Optional:            true,
+						},
+						"hierarchical": schema.BoolAttribute{
+							MarkdownDescription: "Whether the attribute is a parent key for hierarchical dictionaries (`HIERARCHICAL` flag)",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+						"injective": schema.BoolAttribute{
+							MarkdownDescription: "Whether the `id -> attribute` mapping is injective (`INJECTIVE` flag)",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+					},
+				},
+				Validators: []validator.List{listvalidator.SizeAtLeast(1)},
+			},
+			"source": schema.SingleNestedAttribute{
+				MarkdownDescription: "Source of the dictionary data (`SOURCE` clause). Exactly one source should be set",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"clickhouse": schema.SingleNestedAttribute{
+						MarkdownDescription: "ClickHouse table or query. " +
+							"See: https://clickhouse.com/docs/en/sql-reference/dictionaries#clickhouse",
+						Optional: true,
+						Attributes: map[string]schema.Attribute{
+							"host":             schema.StringAttribute{Optional: true, MarkdownDescription: "ClickHouse host. Local server is used if not set"},
+							"port":             schema.Int64Attribute{Optional: true, MarkdownDescription: "Port of the ClickHouse server"},
+							"user":             schema.StringAttribute{Optional: true, MarkdownDescription: "User name"},
+							"password":         schema.StringAttribute{Optional: true, Sensitive: true, MarkdownDescription: "User password"},
+							"db":               schema.StringAttribute{Optional: true, MarkdownDescription: "Database name"},
+							"table":            schema.StringAttribute{Optional: true, MarkdownDescription: "Table name"},
+							"query":            schema.StringAttribute{Optional: true, MarkdownDescription: "Custom query. Cannot be used with `table` and `where`"},
+							"where":            schema.StringAttribute{Optional: true, MarkdownDescription: "Selection criteria"},
+							"invalidate_query": schema.StringAttribute{Optional: true, MarkdownDescription: "Query for checking the dictionary status"},
+							"update_field":     schema.StringAttribute{Optional: true, MarkdownDescription: "Column used for incremental updates"},
+							"update_lag":       schema.Int64Attribute{Optional: true, MarkdownDescription: "Lag in seconds for incremental updates"},
+							"secure":           schema.BoolAttribute{Optional: true, MarkdownDescription: "Whether to use secure connection"},
+						},
+						Validators: []validator.Object{objectvalidator.ExactlyOneOf(sourcePaths...)},
+					},
+					"file": schema.SingleNestedAttribute{
+						MarkdownDescription: "Local file. See: https://clickhouse.com/docs/en/sql-reference/dictionaries#local-file",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"path":   schema.StringAttribute{Required: true, MarkdownDescription: "Absolute path to the file"},
+							"format": schema.StringAttribute{Required: true, MarkdownDescription: "File format"},
+						},
+					},
+					"executable": schema.SingleNestedAttribute{
+						MarkdownDescription: "Executable file. See: https://clickhouse.com/docs/en/sql-reference/dictionaries#executable-file",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"command":      schema.StringAttribute{Required: true, MarkdownDescription: "Command to execute"},
+							"format":       schema.StringAttribute{Required: true, MarkdownDescription: "Output format of the command"},
+							"implicit_key": schema.BoolAttribute{Optional: true, MarkdownDescription: "Whether the command omits key columns in the output"},
+						},
+					},
+					"http": schema.SingleNestedAttribute{
+						MarkdownDescription: "HTTP(S) server. See: https://clickhouse.com/docs/en/sql-reference/dictionaries#https",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"url":      schema.StringAttribute{Required: true, MarkdownDescription: "Source URL"},
+							"format":   schema.StringAttribute{Required: true, MarkdownDescription: "Response format"},
+							"user":     schema.StringAttribute{Optional: true, MarkdownDescription: "User name for basic authentication"},
+							"password": schema.StringAttribute{Optional: true, Sensitive: true, MarkdownDescription: "Password for basic authentication"},
+							"headers": schema.MapAttribute{
+								Optional:            true,
+								ElementType:         types.StringType,
+								MarkdownDescription: "HTTP headers sent with the request",
+							},
+						},
+					},
+				},
+			},
+			"layout": schema.StringAttribute{
+				MarkdownDescription: "Dictionary layout, e.g. `HASHED` or `COMPLEX_KEY_HASHED`. " +
+					"See: https://clickhouse.com/docs/en/sql-reference/dictionaries#ways-to-store-dictionaries-in-memory",
+				Required: true,
+			},
+			"layout_parameters": schema.MapAttribute{
+				MarkdownDescription: "Parameters of the layout, e.g. `{ size_in_cells = 1000000 }`",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"lifetime_min": schema.Int64Attribute{
+				MarkdownDescription: "Minimal interval in seconds between dictionary updates",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+			},
+			"lifetime_max": schema.Int64Attribute{
+				MarkdownDescription: "Maximal interval in seconds between dictionary updates. `0` disables updates",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+			},
+			"range": schema.SingleNestedAttribute{
+				MarkdownDescription: "Range attributes for `RANGE_HASHED` layouts (`RANGE` clause)",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"min": schema.StringAttribute{Required: true, MarkdownDescription: "Attribute with start of the range"},
+					"max": schema.StringAttribute{Required: true, MarkdownDescription: "Attribute with end of the range"},
+				},
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Comment for the dictionary",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"cluster": clusterAttribute(),
+		},
+	}
+}
+
+func (r *DictionaryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	client, err := configureClickHouseClient(ctx, req, resp)
+	if err != nil {
+		return
+	}
+	r.client = client
+}
+
+func (r *DictionaryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model DictionaryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := clusterClient(r.client, model.Cluster).CreateDictionary(ctx, model.toChClientDictionary(), false)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot create dictionary", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DictionaryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model DictionaryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dictionary, err := clusterClient(r.client, model.Cluster).GetDictionary(ctx, model.Database, model.Name)
+	if err != nil {
+		handleNotFoundError(ctx, err, resp, "dictionary", model.FullName.ValueString())
+		return
+	}
+
+	model.fillFromDictionary(dictionary)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DictionaryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planModel DictionaryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := clusterClient(r.client, planModel.Cluster).CreateDictionary(ctx, planModel.toChClientDictionary(), true)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot replace dictionary", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planModel)...)
+}
+
+func (r *DictionaryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model DictionaryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := clusterClient(r.client, model.Cluster).DropDictionary(ctx, model.Database, model.Name)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot drop dictionary", err)
+		return
+	}
+}
+
+func (r *DictionaryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ".")
+
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID should be in `database.dictionary` format",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("full_name"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("layout"), "")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("lifetime_min"), 0)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("lifetime_max"), 0)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("comment"), "")...)
+}
+
+// fillFromDictionary updates the model with the definition of the dictionary in ClickHouse.
+// Configured values are kept if ClickHouse only formats them differently. Passwords are hidden by ClickHouse,
+// so they are kept as is.
+func (model *DictionaryResourceModel) fillFromDictionary(dictionary chclient.ClickHouseDictionary) {
+	model.Database = dictionary.Database
+	model.Name = dictionary.Name
+	model.Comment = dictionary.Comment
+	model.LifetimeMin = int64(dictionary.LifetimeMin)
+	model.LifetimeMax = int64(dictionary.LifetimeMax)
+
+	if !slices.Equal(model.PrimaryKey, dictionary.PrimaryKey) {
+		model.PrimaryKey = dictionary.PrimaryKey
+	}
+
+	configuredAttrs := make(map[string]DictionaryAttributeModel, len(model.Attributes))
+	for _, attr := range model.Attributes {
+		configuredAttrs[attr.Name] = attr
+	}
+	attrs := make([]DictionaryAttributeModel, 0, len(dictionary.Attributes))
+	for _, attr := range dictionary.Attributes {
+		configured := configuredAttrs[attr.Name]
+		actual := DictionaryAttributeModel{
+			Name:         attr.Name,
+			Type:         attr.Type,
+			Default:      keepConfiguredExpression(configured.Default, stringOrNull(attr.Default)),
+			Expression:   keepConfiguredExpression(configured.Expression, stringOrNull(attr.Expression)),
+			Hierarchical: attr.Hierarchical,
+			Injective:    attr.Injective,
+		}
+		if strings.EqualFold(configured.Type, attr.Type) {
+			actual.Type = configured.Type
+		}
+		attrs = append(attrs, actual)
+	}
+	model.Attributes = attrs
+
+	if !chclient.SameDictionaryLayout(model.Layout, dictionary.Layout) {
+		model.Layout = dictionary.Layout
+	}
+	if !sameLayoutParameters(model.LayoutParameters, dictionary.LayoutParams) {
+		model.LayoutParameters = dictionary.LayoutParams
+	}
+
+	if dictionary.RangeMin != "" || dictionary.RangeMax != "" {
+		model.Range = &DictionaryRangeModel{Min: dictionary.RangeMin, Max: dictionary.RangeMax}
+	} else {
+		model.Range = nil
+	}
+
+	model.Source = sourceModelFromChClient(dictionary.Source, model.Source)
+}
+
+// sameLayoutParameters compares layout parameters ignoring case of their names.
+func sameLayoutParameters(configured, actual map[string]string) bool {
+	if len(configured) != len(actual) {
+		return false
+	}
+	for name, value := range configured {
+		if actual[strings.ToLower(name)] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// sourceModelFromChClient converts the source read from ClickHouse into the model.
+// Passwords and unset optional values are taken from the current model.
+func sourceModelFromChClient(source chclient.ClickHouseDictionarySource, current *DictionarySourceModel) *DictionarySourceModel {
+	if current == nil {
+		current = &DictionarySourceModel{}
+	}
+	result := &DictionarySourceModel{}
+
+	if s := source.ClickHouse; s != nil {
+		model := current.ClickHouse
+		if model == nil {
+			model = &DictionaryClickHouseSourceModel{}
+		}
+		result.ClickHouse = &DictionaryClickHouseSourceModel{
+			Host:            keepConfiguredString(model.Host, s.Host),
+			Port:            keepConfiguredInt64(model.Port, int64(s.Port)),
+			User:            keepConfiguredString(model.User, s.User),
+			Password:        model.Password,
+			DB:              keepConfiguredString(model.DB, s.DB),
+			Table:           keepConfiguredString(model.Table, s.Table),
+			Query:           keepConfiguredString(model.Query, s.Query),
+			Where:           keepConfiguredString(model.Where, s.Where),
+			InvalidateQuery: keepConfiguredString(model.InvalidateQuery, s.InvalidateQuery),
+			UpdateField:     keepConfiguredString(model.UpdateField, s.UpdateField),
+			UpdateLag:       keepConfiguredInt64(model.UpdateLag, int64(s.UpdateLag)),
+			Secure:          keepConfiguredBool(model.Secure, s.Secure),
+		}
+	}
+	if s := source.File; s != nil {
+		result.File = &DictionaryFileSourceModel{Path: s.Path, Format: s.Format}
+	}
+	if s := source.Executable; s != nil {
+		var implicitKey types.Bool
+		if current.Executable != nil {
+			implicitKey = current.Executable.ImplicitKey
+		}
+		result.Executable = &DictionaryExecutableSourceModel{
+			Command:     s.Command,
+			Format:      s.Format,
+			ImplicitKey: keepConfiguredBool(implicitKey, s.ImplicitKey),
+		}
+	}
+	if s := source.HTTP; s != nil {
+		model := current.HTTP
+		if model == nil {
+			model = &DictionaryHTTPSourceModel{}
+		}
+		result.HTTP = &DictionaryHTTPSourceModel{
+			URL:      s.URL,
+			Format:   s.Format,
+			User:     keepConfiguredString(model.User, s.User),
+			Password: model.Password,
+			Headers:  s.Headers,
+		}
+	}
+
+	return result
+}
+
+// keepConfiguredString returns the configured value if it matches the actual one. Empty values are null unless configured.
+func keepConfiguredString(configured types.String, actual string) types.String {
+	if configured.ValueString() == actual && !configured.IsUnknown() {
+		return configured
+	}
+	return stringOrNull(actual)
+}
+
+func keepConfiguredInt64(configured types.Int64, actual int64) types.Int64 {
+	if configured.ValueInt64() == actual && !configured.IsUnknown() {
+		return configured
+	}
+	if actual == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(actual)
+}
+
+func keepConfiguredBool(configured types.Bool, actual bool) types.Bool {
+	if configured.ValueBool() == actual && !configured.IsUnknown() {
+		return configured
+	}
+	if !actual {
+		return types.BoolNull()
+	}
+	return types.BoolValue(actual)
+}
+
+func (model DictionaryResourceModel) toChClientDictionary() chclient.ClickHouseDictionary {
+	attrs := make([]chclient.ClickHouseDictionaryAttribute, 0, len(model.Attributes))
+	for _, attr := range model.Attributes {
+		attrs = append(attrs, chclient.ClickHouseDictionaryAttribute{
+			Name:         attr.Name,
+			Type:         attr.Type,
+			Default:      attr.Default.ValueString(),
+			Expression:   attr.Expression.ValueString(),
+			Hierarchical: attr.Hierarchical,
+			Injective:    attr.Injective,
+		})
+	}
+
+	dictionary := chclient.ClickHouseDictionary{
+		Database:     model.Database,
+		Name:         model.Name,
+		PrimaryKey:   model.PrimaryKey,
+		Attributes:   attrs,
+		Layout:       model.Layout,
+		LayoutParams: model.LayoutParameters,
+		LifetimeMin:  uint64(model.LifetimeMin),
+		LifetimeMax:  uint64(model.LifetimeMax),
+		Comment:      model.Comment,
+	}
+
+	if model.Range != nil {
+		dictionary.RangeMin = model.Range.Min
+		dictionary.RangeMax = model.Range.Max
+	}
+
+	if model.Source != nil {
+		dictionary.Source = model.Source.toChClientSource()
+	}
+
+	return dictionary
+}
+
+func (source DictionarySourceModel) toChClientSource() chclient.ClickHouseDictionarySource {
+	var result chclient.ClickHouseDictionarySource
+
+	if s := source.ClickHouse; s != nil {
+		result.ClickHouse = &chclient.ClickHouseDictionaryClickHouseSource{
+			Host:            s.Host.ValueString(),
+			Port:            uint16(s.Port.ValueInt64()),
+			User:            s.User.ValueString(),
+			Password:        s.Password.ValueString(),
+			DB:              s.DB.ValueString(),
+			Table:           s.Table.ValueString(),
+			Query:           s.Query.ValueString(),
+			Where:           s.Where.ValueString(),
+			InvalidateQuery: s.InvalidateQuery.ValueString(),
+			UpdateField:     s.UpdateField.ValueString(),
+			UpdateLag:       uint64(s.UpdateLag.ValueInt64()),
+			Secure:          s.Secure.ValueBool(),
+		}
+	}
+	if s := source.File; s != nil {
+		result.File = &chclient.ClickHouseDictionaryFileSource{Path: s.Path, Format: s.Format}
+	}
+	if s := source.Executable; s != nil {
+		result.Executable = &chclient.ClickHouseDictionaryExecutableSource{
+			Command:     s.Command,
+			Format:      s.Format,
+			ImplicitKey: s.ImplicitKey.ValueBool(),
+		}
+	}
+	if s := source.HTTP; s != nil {
+		result.HTTP = &chclient.ClickHouseDictionaryHTTPSource{
+			URL:      s.URL,
+			Format:   s.Format,
+			User:     s.User.ValueString(),
+			Password: s.Password.ValueString(),
+			Headers:  s.Headers,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDictionaryResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: chDictionaryResource(300),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_dictionary.test", "id", "default.dict_countries"),
+					resource.TestCheckResourceAttr("clickhouse_dictionary.test", "layout", "HASHED"),
+					resource.TestCheckResourceAttr("clickhouse_dictionary.test", "lifetime_max", "300"),
+					resource.TestCheckResourceAttr("clickhouse_dictionary.test", "attributes.1.hierarchical", "true"),
+				),
+			},
+			{
+				Config: chDictionaryResource(600),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_dictionary.test", "lifetime_max", "600"),
+				),
+			},
+			{
+				Config:            chDictionaryResource(600),
+				ResourceName:      "clickhouse_dictionary.test",
+				ImportState:       true,
+				ImportStateId:     "default.dict_countries",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func chDictionaryResource(lifetime int) string {
+	providerConfig := chProviderConfig()
+	resources := fmt.Sprintf(`
+resource "clickhouse_table" "src" {
+  database = "default"
+  name     = "dict_src"
+  engine   = "MergeTree"
+  order_by = ["id"]
+
+  columns = [
+    {
+      name = "id"
+      type = "UInt64"
+    },
+    {
+      name = "parent_id"
+      type = "UInt64"
+    },
+    {
+      name = "name"
+      type = "String"
+    },
+  ]
+}
+
+resource "clickhouse_dictionary" "test" {
+  database    = "default"
+  name        = "dict_countries"
+  primary_key = ["id"]
+
+  attributes = [
+    {
+      name = "id"
+      type = "UInt64"
+    },
+    {
+      name         = "parent_id"
+      type         = "UInt64"
+      default      = "0"
+      hierarchical = true
+    },
+    {
+      name    = "name"
+      type    = "String"
+      default = "''"
+    },
+  ]
+
+  source = {
+    clickhouse = {
+      db    = clickhouse_table.src.database
+      table = clickhouse_table.src.name
+    }
+  }
+
+  layout       = "HASHED"
+  lifetime_max = %[1]d
+  comment      = "countries"
+}
+`, lifetime)
+	return providerConfig + resources
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+// addClientError adds an error returned by chclient to diagnostics.
+// Well-known ClickHouse errors are complemented with a hint on how to resolve them.
+func addClientError(diags *diag.Diagnostics, summary string, err error) {
+	diags.AddError(summary, clientErrorDetail(err))
+}
+
+func clientErrorDetail(err error) string {
+	var (
+		unknownEntityError   *chclient.UnknownEntityError
+		alreadyExistsError   *chclient.AlreadyExistsError
+		accessDeniedError    *chclient.AccessDeniedError
+		timeoutError         *chclient.TimeoutError
+		keeperError          *chclient.KeeperError
+		tableIsNotEmptyError *chclient.TableIsNotEmptyError
+	)
+
+	var hint string
+	switch {
+	case errors.As(err, &unknownEntityError):
+		hint = fmt.Sprintf(
+			"The %s does not exist in ClickHouse. It might have been dropped outside of Terraform "+
+				"or the resource refers to an object that is not created yet.",
+			unknownEntityError.Entity,
+		)
+	case errors.As(err, &alreadyExistsError):
+		hint = fmt.Sprintf(
+			"The %s already exists in ClickHouse. Import it with `terraform import` or choose another name.",
+			alreadyExistsError.Entity,
+		)
+	case errors.As(err, &accessDeniedError):
+		hint = "The ClickHouse user configured in the provider is not allowed to run the query. " +
+			"Check the provider credentials and grant the user the required privileges."
+	case errors.As(err, &timeoutError):
+		hint = "ClickHouse did not finish the query in time. Retry the operation " +
+			"or raise `max_execution_time` for the user configured in the provider."
+	case errors.As(err, &keeperError):
+		hint = "ClickHouse Keeper (ZooKeeper) failed to process the query. Replicated tables and `ON CLUSTER` queries " +
+			"require a healthy Keeper: check its availability and retry the operation."
+	case errors.As(err, &tableIsNotEmptyError):
+		hint = "The provider does not drop tables with data. " +
+			"Truncate the table manually if the data is no longer needed."
+	}
+
+	if hint == "" {
+		return err.Error()
+	}
+	return hint + "\n\nError: " + err.Error()
+}
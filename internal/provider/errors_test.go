@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+func TestClientErrorDetail(t *testing.T) {
+	exception := &chclient.ExceptionError{Code: 57, Name: "TABLE_ALREADY_EXISTS", Message: "Table db.t already exists"}
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "Plain error",
+			err:      errors.New("connection refused"),
+			expected: "connection refused",
+		},
+		{
+			name:     "Already exists",
+			err:      fmt.Errorf("cannot create: %w", &chclient.AlreadyExistsError{ExceptionError: exception, Entity: "table"}),
+			expected: "The table already exists in ClickHouse. Import it with `terraform import` or choose another name.",
+		},
+		{
+			name:     "Table is not empty",
+			err:      &chclient.TableIsNotEmptyError{Table: chclient.ClickHouseTable{Database: "db", Name: "t"}},
+			expected: "The provider does not drop tables with data.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			detail := clientErrorDetail(tc.err)
+			if !strings.HasPrefix(detail, tc.expected) || !strings.HasSuffix(detail, tc.err.Error()) {
+				t.Errorf("expected %q with the error, got %q", tc.expected, detail)
+			}
+		})
+	}
+}
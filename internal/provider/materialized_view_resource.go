@@ -0,0 +1,335 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+var _ resource.Resource = &MaterializedViewResource{}
+var _ resource.ResourceWithImportState = &MaterializedViewResource{}
+var _ resource.ResourceWithModifyPlan = &MaterializedViewResource{}
+
+func NewMaterializedViewResource() resource.Resource {
+	return &MaterializedViewResource{}
+}
+
+type MaterializedViewResource struct {
+	client *chclient.ClickHouseClient
+}
+
+type MaterializedViewResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Database string       `tfsdk:"database"`
+	Name     string       `tfsdk:"name"`
+	FullName types.String `tfsdk:"full_name"`
+	Query    string       `tfsdk:"query"`
+	ToTable  types.String `tfsdk:"to_table"`
+
+	Engine           types.String `tfsdk:"engine"`
+	EngineParameters []string     `tfsdk:"engine_parameters"`
+	PartitionBy      types.String `tfsdk:"partition_by"`
+	OrderBy          []string     `tfsdk:"order_by"`
+	Populate         bool         `tfsdk:"populate"`
+
+	Cluster types.String `tfsdk:"cluster"`
+}
+
+func (r *MaterializedViewResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_materialized_view"
+}
+
+func (r *MaterializedViewResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "ClickHouse materialized view. " +
+			"See: https://clickhouse.com/docs/en/sql-reference/statements/create/view#materialized-view",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				MarkdownDescription: "ClickHouse database name",
+				Required:            true,
+				Validators:          []validator.String{clickHouseIdentifierValidator},
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Materialized view name in ClickHouse database",
+				Required:            true,
+				Validators:          []validator.String{clickHouseIdentifierValidator},
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"full_name": schema.StringAttribute{
+				MarkdownDescription: "ClickHouse materialized view name in `database.view_name` format",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{NewCompositePlanModifierFromStr([]string{"database", "name"}, ".")},
+			},
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{NewCompositePlanModifierFromStr([]string{"database", "name"}, ".")},
+			},
+			"query": schema.StringAttribute{
+				MarkdownDescription: "View definition query. It should be a valid SELECT statement. " +
+					"If `to_table` is set, the query is changed in place with `ALTER TABLE ... MODIFY QUERY`, " +
+					"otherwise the view is re-created",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							var toTable types.String
+							resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("to_table"), &toTable)...)
+							resp.RequiresReplace = toTable.IsNull()
+						},
+						"Query of a materialized view with inner table cannot be changed in place",
+						"Query of a materialized view with inner table cannot be changed in place",
+					),
+				},
+			},
+			"to_table": schema.StringAttribute{
+				MarkdownDescription: "Table in `database.table` format to write data to (`TO` clause). " +
+					"Exactly one of `to_table` and `engine` should be set",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-zA-Z0-9_]+\.[a-zA-Z0-9_]+$`),
+						"Should be in `database.table` format",
+					),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("engine")),
+				},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"engine": schema.StringAttribute{
+				MarkdownDescription: "Engine of the inner table that stores data of the view. " +
+					"See: https://clickhouse.com/docs/en/engines/table-engines",
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"engine_parameters": schema.ListAttribute{
+				MarkdownDescription: "Parameters for engine of the inner table. Will be transformed to `engine(param1, param2, ...)`",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("to_table")),
+				},
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
+			},
+			"partition_by": schema.StringAttribute{
+				MarkdownDescription: "Expression to fill `PARTITION BY` clause of the inner table",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("to_table")),
+				},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"order_by": schema.ListAttribute{
+				MarkdownDescription: "Columns or expressions to fill `ORDER BY` clause of the inner table, e.g. `[\"user_id\", \"toDate(ts)\"]`",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("to_table")),
+				},
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
+			},
+			"populate": schema.BoolAttribute{
+				MarkdownDescription: "Whether to fill the view with existing data of the source table on creation " +
+					"(`POPULATE` clause). Cannot be used with `to_table`",
+				Optional:      true,
+				Computed:      true,
+				Default:       booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+			"cluster": clusterAttribute(),
+		},
+	}
+}
+
+func (r *MaterializedViewResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	client, err := configureClickHouseClient(ctx, req, resp)
+	if err != nil {
+		return
+	}
+	r.client = client
+}
+
+// ModifyPlan reports a query change if the ClickHouse server cannot change the query in place.
+func (r *MaterializedViewResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planQuery, stateQuery, toTable types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("query"), &planQuery)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("query"), &stateQuery)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("to_table"), &toTable)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Views with inner table are re-created when the query changes.
+	if toTable.IsNull() || planQuery.IsUnknown() || planQuery.Equal(stateQuery) {
+		return
+	}
+	requireCapability(&resp.Diagnostics, r.client, chclient.CapabilityModifyQuery, path.Root("query"))
+}
+
+func (r *MaterializedViewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model MaterializedViewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Populate && !model.ToTable.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("populate"),
+			"Cannot populate materialized view",
+			"ClickHouse does not support POPULATE for materialized views with TO clause",
+		)
+		return
+	}
+
+	err := clusterClient(r.client, model.Cluster).CreateMaterializedView(ctx, model.toChClientMaterializedView())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Failed to create a materialized view", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MaterializedViewResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model MaterializedViewResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	view, err := clusterClient(r.client, model.Cluster).GetMaterializedView(ctx, model.Database, model.Name)
+	if err != nil {
+		handleNotFoundError(ctx, err, resp, "materialized view", model.FullName.ValueString())
+		return
+	}
+
+	model.Database = view.Database
+	model.Name = view.Name
+	// ClickHouse formats the query, so the configured one is kept unless it differs in substance.
+	if !chclient.SameExpression(model.Query, view.Query) {
+		model.Query = view.Query
+	}
+
+	if view.ToTable != "" {
+		model.ToTable = types.StringValue(view.ToDatabase + "." + view.ToTable)
+		model.Engine = types.StringNull()
+		model.EngineParameters = nil
+		model.PartitionBy = types.StringNull()
+		model.OrderBy = nil
+	} else {
+		model.ToTable = types.StringNull()
+		model.Engine = types.StringValue(view.Engine)
+		if len(view.EngineParams) > 0 || model.EngineParameters != nil {
+			model.EngineParameters = view.EngineParams
+		}
+		if view.PartitionBy != "" || !model.PartitionBy.IsNull() {
+			model.PartitionBy = types.StringValue(view.PartitionBy)
+		}
+		if (len(view.OrderBy) > 0 || model.OrderBy != nil) && !chclient.SameExpressions(model.OrderBy, view.OrderBy) {
+			model.OrderBy = view.OrderBy
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MaterializedViewResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var stateModel MaterializedViewResourceModel
+	var planModel MaterializedViewResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planModel.Query != stateModel.Query {
+		err := clusterClient(r.client, planModel.Cluster).ModifyMaterializedViewQuery(
+			ctx,
+			planModel.Database,
+			planModel.Name,
+			planModel.Query,
+		)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Cannot modify query of materialized view", err)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planModel)...)
+}
+
+func (r *MaterializedViewResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model MaterializedViewResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	view := chclient.ClickHouseTable{Database: model.Database, Name: model.Name}
+	err := clusterClient(r.client, model.Cluster).DropTable(ctx, view, false)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot drop materialized view", err)
+		return
+	}
+}
+
+func (r *MaterializedViewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ".")
+
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID should be in `database.view_name` format",
+		)
+		return
+	}
+	db := parts[0]
+	view := parts[1]
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("full_name"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), db)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), view)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("query"), "")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("populate"), false)...)
+}
+
+func (model MaterializedViewResourceModel) toChClientMaterializedView() chclient.ClickHouseMaterializedView {
+	view := chclient.ClickHouseMaterializedView{
+		Database:     model.Database,
+		Name:         model.Name,
+		Query:        model.Query,
+		Engine:       model.Engine.ValueString(),
+		EngineParams: model.EngineParameters,
+		PartitionBy:  model.PartitionBy.ValueString(),
+		OrderBy:      model.OrderBy,
+		Populate:     model.Populate,
+	}
+
+	if toDatabase, toTable, found := strings.Cut(model.ToTable.ValueString(), "."); found {
+		view.ToDatabase = toDatabase
+		view.ToTable = toTable
+	}
+
+	return view
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMaterializedViewResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: chMaterializedViewResource("SELECT id FROM default.mv_src"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_materialized_view.to_table", "id", "default.mv_to_table"),
+					resource.TestCheckResourceAttr("clickhouse_materialized_view.to_table", "to_table", "default.mv_dst"),
+					resource.TestCheckResourceAttr("clickhouse_materialized_view.to_table", "query", "SELECT id FROM default.mv_src"),
+					resource.TestCheckResourceAttr("clickhouse_materialized_view.inner", "id", "default.mv_inner"),
+					resource.TestCheckResourceAttr("clickhouse_materialized_view.inner", "engine", "MergeTree"),
+					resource.TestCheckResourceAttr("clickhouse_materialized_view.inner", "order_by.0", "id"),
+				),
+			},
+			{
+				// The query is kept as configured although ClickHouse formats it differently.
+				Config: chMaterializedViewResource("SELECT id + 1 AS id\n  FROM `default`.`mv_src`"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_materialized_view.to_table", "query", "SELECT id + 1 AS id\n  FROM `default`.`mv_src`"),
+				),
+			},
+			{
+				Config: chMaterializedViewResource("SELECT id + 1 AS id FROM default.mv_src"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_materialized_view.to_table", "query", "SELECT id + 1 AS id FROM default.mv_src"),
+				),
+			},
+			{
+				Config:                  chMaterializedViewResource("SELECT id + 1 AS id FROM default.mv_src"),
+				ResourceName:            "clickhouse_materialized_view.to_table",
+				ImportState:             true,
+				ImportStateId:           "default.mv_to_table",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"populate"},
+			},
+			{
+				Config:                  chMaterializedViewResource("SELECT id + 1 AS id FROM default.mv_src"),
+				ResourceName:            "clickhouse_materialized_view.inner",
+				ImportState:             true,
+				ImportStateId:           "default.mv_inner",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"populate"},
+			},
+		},
+	})
+}
+
+func chMaterializedViewResource(query string) string {
+	providerConfig := chProviderConfig()
+	resources := fmt.Sprintf(`
+resource "clickhouse_table" "src" {
+  database = "default"
+  name     = "mv_src"
+  engine   = "MergeTree"
+  order_by = ["id"]
+
+  columns = [
+    {
+      name = "id"
+      type = "UInt64"
+    },
+  ]
+}
+
+resource "clickhouse_table" "dst" {
+  database = "default"
+  name     = "mv_dst"
+  engine   = "MergeTree"
+  order_by = ["id"]
+
+  columns = [
+    {
+      name = "id"
+      type = "UInt64"
+    },
+  ]
+}
+
+resource "clickhouse_materialized_view" "to_table" {
+  database = "default"
+  name     = "mv_to_table"
+  to_table = clickhouse_table.dst.full_name
+  query    = %[1]q
+
+  depends_on = [clickhouse_table.src]
+}
+
+resource "clickhouse_materialized_view" "inner" {
+  database = "default"
+  name     = "mv_inner"
+  engine   = "MergeTree"
+  order_by = ["id"]
+  query    = "SELECT id FROM ${clickhouse_table.src.full_name}"
+}
+`, query)
+	return providerConfig + resources
+}
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -43,6 +42,7 @@ type PrivilegeGrantResourceModel struct {
 	Grantee    string        `tfsdk:"grantee"`
 	AccessType string        `tfsdk:"access_type"`
 	Grants     []GrantRecord `tfsdk:"grants"`
+	Cluster    types.String  `tfsdk:"cluster"`
 }
 
 func (r *PrivilegeGrantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -107,6 +107,7 @@ func (r *PrivilegeGrantResource) Schema(ctx context.Context, req resource.Schema
 					},
 				},
 			},
+			"cluster": clusterAttribute(),
 		},
 	}
 }
@@ -126,16 +127,16 @@ func (r *PrivilegeGrantResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	_, err := r.client.GetPrivilegeGrants(ctx, model.Grantee, model.AccessType)
+	client := clusterClient(r.client, model.Cluster)
+	_, err := client.GetPrivilegeGrants(ctx, model.Grantee, model.AccessType)
 	if err != nil {
-		var notFoundError *chclient.NotFoundError
-		ok := errors.As(err, &notFoundError)
-		if !ok {
-			resp.Diagnostics.AddError(
+		if !chclient.IsNotFound(err) {
+			addClientError(
+				&resp.Diagnostics,
 				"Cannot check whether privilege grants already exist. "+
 					"This check is required due to the requirement that every `privilege_grant` "+
 					"resource must have unique (`grantee`, `access_type`) pair.",
-				err.Error(),
+				err,
 			)
 			return
 		}
@@ -157,9 +158,9 @@ func (r *PrivilegeGrantResource) Create(ctx context.Context, req resource.Create
 			Columns:     grant.Columns,
 			GrantOption: grant.WithGrantOption,
 		}
-		err := r.client.GrantPrivilege(ctx, g)
+		err := client.GrantPrivilege(ctx, g)
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to grant privilege", err.Error())
+			addClientError(&resp.Diagnostics, "Failed to grant privilege", err)
 			return
 		}
 	}
@@ -175,7 +176,7 @@ func (r *PrivilegeGrantResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	receivedGrants, err := r.client.GetPrivilegeGrants(ctx, model.Grantee, model.AccessType)
+	receivedGrants, err := clusterClient(r.client, model.Cluster).GetPrivilegeGrants(ctx, model.Grantee, model.AccessType)
 	if err != nil {
 		name := fmt.Sprintf("(grantee=%s, access_type=%s)", model.Grantee, model.AccessType)
 		handleNotFoundError(ctx, err, resp, "privilege grant", name)
@@ -222,9 +223,9 @@ func (r *PrivilegeGrantResource) Delete(ctx context.Context, req resource.Delete
 		Table:      "*",
 		Columns:    make([]string, 0),
 	}
-	err := r.client.RevokePrivilege(ctx, grant)
+	err := clusterClient(r.client, model.Cluster).RevokePrivilege(ctx, grant)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to revoke privilege", err.Error())
+		addClientError(&resp.Diagnostics, "Failed to revoke privilege", err)
 		return
 	}
 }
@@ -8,7 +8,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
 
@@ -31,11 +33,83 @@ type ClickHouseProvider struct {
 }
 
 type ClickHouseProviderModel struct {
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
-	Host     types.String `tfsdk:"host"`
-	Port     types.Int64  `tfsdk:"port"`
-	Protocol types.String `tfsdk:"protocol"`
+	Username types.String                  `tfsdk:"username"`
+	Password types.String                  `tfsdk:"password"`
+	Host     types.String                  `tfsdk:"host"`
+	Port     types.Int64                   `tfsdk:"port"`
+	Protocol types.String                  `tfsdk:"protocol"`
+	Cluster  types.String                  `tfsdk:"cluster"`
+	TLS      *ClickHouseProviderTLSModel   `tfsdk:"tls"`
+	Retry    *ClickHouseProviderRetryModel `tfsdk:"retry"`
+}
+
+type ClickHouseProviderTLSModel struct {
+	CAFile             types.String `tfsdk:"ca_file"`
+	CAPEM              types.String `tfsdk:"ca_pem"`
+	CertFile           types.String `tfsdk:"cert_file"`
+	CertPEM            types.String `tfsdk:"cert_pem"`
+	KeyFile            types.String `tfsdk:"key_file"`
+	KeyPEM             types.String `tfsdk:"key_pem"`
+	ServerName         types.String `tfsdk:"server_name"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+}
+
+func (m *ClickHouseProviderTLSModel) ToTLSOptions() *chclient.TLSOptions {
+	if m == nil {
+		return nil
+	}
+
+	return &chclient.TLSOptions{
+		CAFile:             m.CAFile.ValueString(),
+		CAPEM:              m.CAPEM.ValueString(),
+		CertFile:           m.CertFile.ValueString(),
+		CertPEM:            m.CertPEM.ValueString(),
+		KeyFile:            m.KeyFile.ValueString(),
+		KeyPEM:             m.KeyPEM.ValueString(),
+		ServerName:         m.ServerName.ValueString(),
+		InsecureSkipVerify: m.InsecureSkipVerify.ValueBool(),
+	}
+}
+
+type ClickHouseProviderRetryModel struct {
+	MaxAttempts         types.Int64  `tfsdk:"max_attempts"`
+	InitialBackoff      types.String `tfsdk:"initial_backoff"`
+	MaxBackoff          types.String `tfsdk:"max_backoff"`
+	RetryableErrorCodes []int64      `tfsdk:"retryable_error_codes"`
+}
+
+// ToRetryPolicy returns chclient.DefaultRetryPolicy with the configured values.
+func (m *ClickHouseProviderRetryModel) ToRetryPolicy() (*chclient.RetryPolicy, error) {
+	policy := chclient.DefaultRetryPolicy()
+	if m == nil {
+		return &policy, nil
+	}
+
+	if !m.MaxAttempts.IsNull() {
+		policy.MaxAttempts = int(m.MaxAttempts.ValueInt64())
+	}
+	if !m.InitialBackoff.IsNull() {
+		backoff, err := time.ParseDuration(m.InitialBackoff.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("invalid initial_backoff: %w", err)
+		}
+		policy.InitialBackoff = backoff
+	}
+	if !m.MaxBackoff.IsNull() {
+		backoff, err := time.ParseDuration(m.MaxBackoff.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_backoff: %w", err)
+		}
+		policy.MaxBackoff = backoff
+	}
+	if m.RetryableErrorCodes != nil {
+		policy.RetryableCodes = make([]int32, 0, len(m.RetryableErrorCodes))
+		for _, code := range m.RetryableErrorCodes {
+			policy.RetryableCodes = append(policy.RetryableCodes, int32(code))
+		}
+	}
+
+	return &policy, nil
 }
 
 func (p *ClickHouseProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -56,8 +130,9 @@ func (p *ClickHouseProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Description: "ClickHouse host, e.g. `localhost`",
 			},
 			"port": schema.Int64Attribute{
-				Optional:    true,
-				Description: "ClickHouse port, e.g. 9000. If not specified, default port will be used (8123 for `http` and 9000 for `native`)",
+				Optional: true,
+				Description: "ClickHouse port, e.g. 9000. If not specified, default port will be used " +
+					"(8123 for `http` and 9000 for `native`, or 8443 and 9440 respectively if `tls` is set)",
 			},
 			"username": schema.StringAttribute{
 				MarkdownDescription: "ClickHouse user that have enough permissions to manage databases, users, tables, etc.",
@@ -68,10 +143,128 @@ func (p *ClickHouseProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Required:            true,
 				Sensitive:           true,
 			},
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of a cluster to run DDL queries on. If set, every query managing " +
+					"databases, tables, users, etc. will contain `ON CLUSTER` clause, and resources will be " +
+					"checked to exist on all replicas of the cluster. Can be overridden by `cluster` attribute of a resource",
+				Optional: true,
+			},
+			"tls": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Enables TLS for connection to ClickHouse (both `native` and `http` protocols). " +
+					"Certificates and keys can be set either as paths to PEM files or as PEM-encoded content. " +
+					"Set an empty block (`tls = {}`) to use TLS with the system CA pool",
+				Attributes: map[string]schema.Attribute{
+					"ca_file": schema.StringAttribute{
+						MarkdownDescription: "Path to PEM-encoded CA bundle used to verify the server certificate",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("ca_pem")),
+						},
+					},
+					"ca_pem": schema.StringAttribute{
+						MarkdownDescription: "PEM-encoded CA bundle used to verify the server certificate",
+						Optional:            true,
+					},
+					"cert_file": schema.StringAttribute{
+						MarkdownDescription: "Path to PEM-encoded client certificate for mutual TLS",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("cert_pem")),
+							stringvalidator.AtLeastOneOf(
+								path.MatchRelative().AtParent().AtName("key_file"),
+								path.MatchRelative().AtParent().AtName("key_pem"),
+							),
+						},
+					},
+					"cert_pem": schema.StringAttribute{
+						MarkdownDescription: "PEM-encoded client certificate for mutual TLS",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.AtLeastOneOf(
+								path.MatchRelative().AtParent().AtName("key_file"),
+								path.MatchRelative().AtParent().AtName("key_pem"),
+							),
+						},
+					},
+					"key_file": schema.StringAttribute{
+						MarkdownDescription: "Path to PEM-encoded private key of the client certificate",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("key_pem")),
+							stringvalidator.AtLeastOneOf(
+								path.MatchRelative().AtParent().AtName("cert_file"),
+								path.MatchRelative().AtParent().AtName("cert_pem"),
+							),
+						},
+					},
+					"key_pem": schema.StringAttribute{
+						MarkdownDescription: "PEM-encoded private key of the client certificate",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.AtLeastOneOf(
+								path.MatchRelative().AtParent().AtName("cert_file"),
+								path.MatchRelative().AtParent().AtName("cert_pem"),
+							),
+						},
+					},
+					"server_name": schema.StringAttribute{
+						MarkdownDescription: "Overrides server name used to verify the server certificate (SNI). " +
+							"Useful when ClickHouse is reached by IP address or through a load balancer",
+						Optional: true,
+					},
+					"insecure_skip_verify": schema.BoolAttribute{
+						MarkdownDescription: "Disables verification of the server certificate. " +
+							"**Never use it in production**",
+						Optional: true,
+					},
+				},
+			},
+			"retry": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Retry policy for queries failed due to transient errors, e.g. network failures or " +
+					"`TOO_MANY_SIMULTANEOUS_QUERIES`. Queries which might have been executed by a failed attempt are retried " +
+					"only if it is safe: `CREATE` is not repeated if the object already exists, and other non-idempotent queries " +
+					"such as `ALTER` are retried only if ClickHouse rejected them. " +
+					"By default, every query is run up to 3 times with backoff from 1s to 30s",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						MarkdownDescription: "Maximum number of attempts to run a query including the first one. " +
+							"Set to 1 to disable retries. Defaults to 3",
+						Optional:   true,
+						Validators: []validator.Int64{int64validator.AtLeast(1)},
+					},
+					"initial_backoff": schema.StringAttribute{
+						MarkdownDescription: "Delay before the first retry, e.g. `500ms`. It is doubled after every attempt. Defaults to `1s`",
+						Optional:            true,
+						Validators:          []validator.String{durationValidator{}},
+					},
+					"max_backoff": schema.StringAttribute{
+						MarkdownDescription: "Maximum delay between attempts. Defaults to `30s`",
+						Optional:            true,
+						Validators:          []validator.String{durationValidator{}},
+					},
+					"retryable_error_codes": schema.ListAttribute{
+						MarkdownDescription: "Codes of ClickHouse exceptions to retry. Network errors are always retried. " +
+							"Defaults to " + retryableCodesDescription(),
+						Optional:    true,
+						ElementType: types.Int64Type,
+					},
+				},
+			},
 		},
 	}
 }
 
+func retryableCodesDescription() string {
+	codes := make([]string, 0, len(chclient.DefaultRetryableCodes))
+	for _, code := range chclient.DefaultRetryableCodes {
+		codes = append(codes, fmt.Sprintf("`%d`", code))
+	}
+	return strings.Join(codes, ", ")
+}
+
 func (p *ClickHouseProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var data ClickHouseProviderModel
 
@@ -97,9 +290,14 @@ func (p *ClickHouseProvider) Configure(ctx context.Context, req provider.Configu
 	}
 
 	if data.Port.IsNull() {
-		if protocol == "http" {
+		switch {
+		case protocol == "http" && data.TLS != nil:
+			port = 8443
+		case protocol == "http":
 			port = 8123
-		} else {
+		case data.TLS != nil:
+			port = 9440
+		default:
 			port = 9000
 		}
 	} else {
@@ -120,6 +318,12 @@ func (p *ClickHouseProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
+	retryPolicy, err := data.Retry.ToRetryPolicy()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid retry policy", err.Error())
+		return
+	}
+
 	client, err := chclient.NewClickHouseClient(&clickhouse.Options{
 		Addr: []string{addr},
 		Auth: clickhouse.Auth{
@@ -135,15 +339,13 @@ func (p *ClickHouseProvider) Configure(ctx context.Context, req provider.Configu
 			Method: clickhouse.CompressionLZ4,
 		},
 		Protocol: proto,
-	})
+	}, data.TLS.ToTLSOptions(), retryPolicy)
 
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot connect to ClickHouse",
-			"Cannot connect to ClickHouse: "+err.Error()+
-				"\naddr: "+addr,
-		)
+		addClientError(&resp.Diagnostics, "Cannot connect to ClickHouse", fmt.Errorf("addr %s: %w", addr, err))
+		return
 	}
+	client.Cluster = data.Cluster.ValueString()
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
@@ -158,6 +360,11 @@ func (p *ClickHouseProvider) Resources(ctx context.Context) []func() resource.Re
 		NewRoleGrantResource,
 		NewPrivilegeGrantResource,
 		NewViewResource,
+		NewMaterializedViewResource,
+		NewDictionaryResource,
+		NewSettingsProfileResource,
+		NewQuotaResource,
+		NewRowPolicyResource,
 	}
 }
 
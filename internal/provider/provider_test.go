@@ -1,10 +1,15 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"os"
 	"testing"
 
+	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -21,8 +26,15 @@ func testAccPreCheck(t *testing.T) {
 	// function.
 }
 
+// chProviderConfig configures the provider for the test ClickHouse server.
+// The protocol is taken from TESTS_TF_CH_PROTOCOL environment variable, native is used by default.
 func chProviderConfig() string {
-	return `terraform {
+	protocol, port := "native", 9000
+	if os.Getenv("TESTS_TF_CH_PROTOCOL") == "http" {
+		protocol, port = "http", 8123
+	}
+
+	return fmt.Sprintf(`terraform {
   required_providers {
     clickhouse = {
       source = "vegassor/clickhouse"
@@ -34,8 +46,51 @@ provider "clickhouse" {
   username = "default"
   password = "default"
   host     = "localhost"
-  port     = 9000
-  protocol = "native"
+  port     = %d
+  protocol = %q
+}
+`, port, protocol)
+}
+
+// testAccExec runs a query against the test ClickHouse server, e.g. to make changes outside Terraform.
+func testAccExec(t *testing.T, query string) {
+	t.Helper()
+
+	conn, err := clickhouse.Open(&clickhouse.Options{
+		Addr: []string{"localhost:9000"},
+		Auth: clickhouse.Auth{Username: "default", Password: "default"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if err = conn.Exec(context.Background(), query); err != nil {
+		t.Fatal(err)
+	}
 }
-`
+
+// testAccSupports reports whether the test ClickHouse server has the capability.
+func testAccSupports(t *testing.T, capability chclient.Capability) bool {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests skipped unless env 'TF_ACC' set")
+	}
+
+	conn, err := clickhouse.Open(&clickhouse.Options{
+		Addr: []string{"localhost:9000"},
+		Auth: clickhouse.Auth{Username: "default", Password: "default"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	version, err := conn.ServerVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := chclient.ClickHouseClient{Conn: conn, ServerVersion: version.Version}
+	return client.Supports(capability)
 }
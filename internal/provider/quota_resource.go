@@ -0,0 +1,329 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+var _ resource.Resource = &QuotaResource{}
+var _ resource.ResourceWithImportState = &QuotaResource{}
+
+func NewQuotaResource() resource.Resource {
+	return &QuotaResource{}
+}
+
+type QuotaResource struct {
+	client *chclient.ClickHouseClient
+}
+
+type QuotaIntervalModel struct {
+	Duration         string        `tfsdk:"duration"`
+	Randomized       bool          `tfsdk:"randomized"`
+	MaxQueries       types.Int64   `tfsdk:"max_queries"`
+	MaxQuerySelects  types.Int64   `tfsdk:"max_query_selects"`
+	MaxQueryInserts  types.Int64   `tfsdk:"max_query_inserts"`
+	MaxErrors        types.Int64   `tfsdk:"max_errors"`
+	MaxResultRows    types.Int64   `tfsdk:"max_result_rows"`
+	MaxResultBytes   types.Int64   `tfsdk:"max_result_bytes"`
+	MaxReadRows      types.Int64   `tfsdk:"max_read_rows"`
+	MaxReadBytes     types.Int64   `tfsdk:"max_read_bytes"`
+	MaxExecutionTime types.Float64 `tfsdk:"max_execution_time"`
+}
+
+type QuotaResourceModel struct {
+	ID        types.String         `tfsdk:"id"`
+	Name      string               `tfsdk:"name"`
+	KeyedBy   types.String         `tfsdk:"keyed_by"`
+	Intervals []QuotaIntervalModel `tfsdk:"intervals"`
+	ApplyTo   *ApplyToModel        `tfsdk:"apply_to"`
+	Cluster   types.String         `tfsdk:"cluster"`
+}
+
+func (r *QuotaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_quota"
+}
+
+func quotaLimitAttribute(description string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: description,
+		Optional:            true,
+	}
+}
+
+func (r *QuotaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "ClickHouse quota. See: https://clickhouse.com/docs/en/sql-reference/statements/create/quota",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Quota name in ClickHouse",
+				Required:            true,
+				Validators:          []validator.String{clickHouseIdentifierValidator},
+			},
+			"keyed_by": schema.StringAttribute{
+				MarkdownDescription: "Key to track quota consumption by (`KEYED BY` clause). " +
+					"If not set, the quota is tracked for all users together (`NOT KEYED`)",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf(chclient.QuotaKeys...)},
+			},
+			"intervals": schema.ListNestedAttribute{
+				MarkdownDescription: "Intervals with limits (`FOR INTERVAL` elements). " +
+					"An interval without limits only tracks consumption (`TRACKING ONLY`)",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"duration": schema.StringAttribute{
+							MarkdownDescription: "Interval length, e.g. `1 hour` or `30 minutes`",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(
+									regexp.MustCompile(`^(?i)\d+ (second|minute|hour|day|week|month|quarter|year)s?$`),
+									"Should be in `<number> <unit>` format, e.g. `1 hour`",
+								),
+							},
+						},
+						"randomized": schema.BoolAttribute{
+							MarkdownDescription: "Whether the interval starts at a random moment (`RANDOMIZED`)",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+						"max_queries":       quotaLimitAttribute("Maximal number of queries"),
+						"max_query_selects": quotaLimitAttribute("Maximal number of SELECT queries"),
+						"max_query_inserts": quotaLimitAttribute("Maximal number of INSERT queries"),
+						"max_errors":        quotaLimitAttribute("Maximal number of queries that threw an exception"),
+						"max_result_rows":   quotaLimitAttribute("Maximal number of rows given as a result"),
+						"max_result_bytes":  quotaLimitAttribute("Maximal number of bytes given as a result"),
+						"max_read_rows":     quotaLimitAttribute("Maximal number of source rows read from tables"),
+						"max_read_bytes":    quotaLimitAttribute("Maximal number of bytes read from tables"),
+						"max_execution_time": schema.Float64Attribute{
+							MarkdownDescription: "Maximal total query execution time in seconds",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"apply_to": applyToAttribute("quota"),
+			"cluster":  clusterAttribute(),
+		},
+	}
+}
+
+func (r *QuotaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	client, err := configureClickHouseClient(ctx, req, resp)
+	if err != nil {
+		return
+	}
+	r.client = client
+}
+
+func (r *QuotaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model QuotaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	quota, diags := model.toChClientQuota()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := clusterClient(r.client, model.Cluster).CreateQuota(ctx, quota)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot create quota", err)
+		return
+	}
+
+	model.ID = types.StringValue(model.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *QuotaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model QuotaResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	quota, err := clusterClient(r.client, model.Cluster).GetQuota(ctx, model.Name)
+	if err != nil {
+		handleNotFoundError(ctx, err, resp, "quota", model.Name)
+		return
+	}
+
+	model.ID = types.StringValue(quota.Name)
+	model.Name = quota.Name
+	model.KeyedBy = stringOrNull(quota.KeyedBy)
+	model.Intervals = fromChClientQuotaLimits(quota.Limits, model.Intervals)
+	model.ApplyTo = fromChClientApplyTo(quota.ApplyTo)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *QuotaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var stateModel QuotaResourceModel
+	var planModel QuotaResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	origQuota, diags := stateModel.toChClientQuota()
+	resp.Diagnostics.Append(diags...)
+	quota, diags := planModel.toChClientQuota()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := clusterClient(r.client, planModel.Cluster).AlterQuota(ctx, origQuota, quota)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot alter quota", err)
+		return
+	}
+
+	planModel.ID = types.StringValue(planModel.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planModel)...)
+}
+
+func (r *QuotaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model QuotaResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := clusterClient(r.client, model.Cluster).DropQuota(ctx, model.Name)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot drop quota", err)
+		return
+	}
+}
+
+func (r *QuotaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func int64Pointer(v types.Int64) *uint64 {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	result := uint64(v.ValueInt64())
+	return &result
+}
+
+func int64FromPointer(v *uint64) types.Int64 {
+	if v == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*v))
+}
+
+func (model QuotaResourceModel) toChClientQuota() (chclient.ClickHouseQuota, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	limits := make([]chclient.ClickHouseQuotaLimits, 0, len(model.Intervals))
+	for i, interval := range model.Intervals {
+		duration, err := chclient.ParseQuotaDuration(interval.Duration)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("intervals").AtListIndex(i).AtName("duration"),
+				"Invalid quota duration",
+				err.Error(),
+			)
+			continue
+		}
+
+		var maxExecutionTime *float64
+		if !interval.MaxExecutionTime.IsNull() && !interval.MaxExecutionTime.IsUnknown() {
+			value := interval.MaxExecutionTime.ValueFloat64()
+			maxExecutionTime = &value
+		}
+
+		limits = append(limits, chclient.ClickHouseQuotaLimits{
+			Duration:         duration,
+			Randomized:       interval.Randomized,
+			MaxQueries:       int64Pointer(interval.MaxQueries),
+			MaxQuerySelects:  int64Pointer(interval.MaxQuerySelects),
+			MaxQueryInserts:  int64Pointer(interval.MaxQueryInserts),
+			MaxErrors:        int64Pointer(interval.MaxErrors),
+			MaxResultRows:    int64Pointer(interval.MaxResultRows),
+			MaxResultBytes:   int64Pointer(interval.MaxResultBytes),
+			MaxReadRows:      int64Pointer(interval.MaxReadRows),
+			MaxReadBytes:     int64Pointer(interval.MaxReadBytes),
+			MaxExecutionTime: maxExecutionTime,
+		})
+	}
+
+	return chclient.ClickHouseQuota{
+		Name:    model.Name,
+		KeyedBy: model.KeyedBy.ValueString(),
+		Limits:  limits,
+		ApplyTo: model.ApplyTo.toChClientApplyTo(),
+	}, diags
+}
+
+// fromChClientQuotaLimits converts intervals received from ClickHouse keeping the order
+// and duration format of the intervals from the state.
+func fromChClientQuotaLimits(limits []chclient.ClickHouseQuotaLimits, stateIntervals []QuotaIntervalModel) []QuotaIntervalModel {
+	byDuration := make(map[uint32]chclient.ClickHouseQuotaLimits, len(limits))
+	for _, l := range limits {
+		byDuration[l.Duration] = l
+	}
+
+	var intervals []QuotaIntervalModel
+	appendInterval := func(l chclient.ClickHouseQuotaLimits, duration string) {
+		maxExecutionTime := types.Float64Null()
+		if l.MaxExecutionTime != nil {
+			maxExecutionTime = types.Float64Value(*l.MaxExecutionTime)
+		}
+
+		intervals = append(intervals, QuotaIntervalModel{
+			Duration:         duration,
+			Randomized:       l.Randomized,
+			MaxQueries:       int64FromPointer(l.MaxQueries),
+			MaxQuerySelects:  int64FromPointer(l.MaxQuerySelects),
+			MaxQueryInserts:  int64FromPointer(l.MaxQueryInserts),
+			MaxErrors:        int64FromPointer(l.MaxErrors),
+			MaxResultRows:    int64FromPointer(l.MaxResultRows),
+			MaxResultBytes:   int64FromPointer(l.MaxResultBytes),
+			MaxReadRows:      int64FromPointer(l.MaxReadRows),
+			MaxReadBytes:     int64FromPointer(l.MaxReadBytes),
+			MaxExecutionTime: maxExecutionTime,
+		})
+		delete(byDuration, l.Duration)
+	}
+
+	for _, interval := range stateIntervals {
+		duration, err := chclient.ParseQuotaDuration(interval.Duration)
+		if err != nil {
+			continue
+		}
+		if l, found := byDuration[duration]; found {
+			appendInterval(l, interval.Duration)
+		}
+	}
+
+	for _, l := range limits {
+		if _, found := byDuration[l.Duration]; found {
+			appendInterval(l, chclient.FormatQuotaDuration(l.Duration))
+		}
+	}
+
+	return intervals
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccQuotaResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: chQuotaResource("myquota", 100),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "id", "myquota"),
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "keyed_by", "user_name"),
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "intervals.0.duration", "1 hour"),
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "intervals.0.max_queries", "100"),
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "intervals.1.randomized", "true"),
+				),
+			},
+			{
+				Config: chQuotaResource("myquota2", 200),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "id", "myquota2"),
+					resource.TestCheckResourceAttr("clickhouse_quota.test", "intervals.0.max_queries", "200"),
+				),
+			},
+			{
+				Config:            chQuotaResource("myquota2", 200),
+				ResourceName:      "clickhouse_quota.test",
+				ImportState:       true,
+				ImportStateId:     "myquota2",
+				ImportStateVerify: true,
+				// Durations are imported in a canonical form.
+				ImportStateVerifyIgnore: []string{"intervals.1.duration"},
+			},
+		},
+	})
+}
+
+func chQuotaResource(name string, maxQueries int) string {
+	providerConfig := chProviderConfig()
+	resources := fmt.Sprintf(`
+resource "clickhouse_role" "test" {
+  name = "quota_role"
+}
+
+resource "clickhouse_quota" "test" {
+  name     = %[1]q
+  keyed_by = "user_name"
+
+  intervals = [
+    {
+      duration           = "1 hour"
+      max_queries        = %[2]d
+      max_execution_time = 1.5
+    },
+    {
+      duration   = "2 days"
+      randomized = true
+      max_errors = 10
+    },
+  ]
+
+  apply_to = {
+    all    = true
+    except = [clickhouse_role.test.name]
+  }
+}
+`, name, maxQueries)
+	return providerConfig + resources
+}
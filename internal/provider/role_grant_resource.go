@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
@@ -35,6 +34,7 @@ type RoleGrantResourceModel struct {
 	Grantee         string       `tfsdk:"grantee"`
 	Role            string       `tfsdk:"role"`
 	WithAdminOption bool         `tfsdk:"with_admin_option"`
+	Cluster         types.String `tfsdk:"cluster"`
 }
 
 func (r *RoleGrantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -70,6 +70,7 @@ func (r *RoleGrantResource) Schema(ctx context.Context, req resource.SchemaReque
 				Default:             booldefault.StaticBool(false),
 				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
 			},
+			"cluster": clusterAttribute(),
 		},
 	}
 }
@@ -90,12 +91,9 @@ func (r *RoleGrantResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	err := r.client.GrantRole(ctx, model.Role, model.Grantee, model.WithAdminOption)
+	err := clusterClient(r.client, model.Cluster).GrantRole(ctx, model.Role, model.Grantee, model.WithAdminOption)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot grant role",
-			"GRANT query failed: "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot grant role", err)
 		return
 	}
 
@@ -110,7 +108,7 @@ func (r *RoleGrantResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	receivedGrant, err := r.client.GetRoleGrant(ctx, model.Role, model.Grantee)
+	receivedGrant, err := clusterClient(r.client, model.Cluster).GetRoleGrant(ctx, model.Role, model.Grantee)
 	if err != nil {
 		name := fmt.Sprintf("(role=%s, grantee=%s)", model.Role, model.Grantee)
 		handleNotFoundError(ctx, err, resp, "role grant", name)
@@ -138,21 +136,17 @@ func (r *RoleGrantResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	err := r.client.RevokeRole(ctx, model.Role, model.Grantee)
+	err := clusterClient(r.client, model.Cluster).RevokeRole(ctx, model.Role, model.Grantee)
 	if err == nil {
 		return
 	}
 
-	var notFoundError *chclient.NotFoundError
-	if errors.As(err, &notFoundError) {
+	if chclient.IsNotFound(err) {
 		tflog.Info(ctx, "Role grant already deleted", dict{"err": err.Error()})
 		return
 	}
 
-	resp.Diagnostics.AddError(
-		"Cannot revoke role grant",
-		err.Error(),
-	)
+	addClientError(&resp.Diagnostics, "Cannot revoke role grant", err)
 }
 
 func (r *RoleGrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -22,8 +22,9 @@ type RoleResource struct {
 }
 
 type RoleResourceModel struct {
-	ID   types.String `tfsdk:"id"`
-	Name string       `tfsdk:"name"`
+	ID      types.String `tfsdk:"id"`
+	Name    string       `tfsdk:"name"`
+	Cluster types.String `tfsdk:"cluster"`
 }
 
 func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -42,6 +43,7 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Required:            true,
 				Validators:          []validator.String{clickHouseIdentifierValidator},
 			},
+			"cluster": clusterAttribute(),
 		},
 	}
 }
@@ -62,12 +64,9 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	err := r.client.CreateRole(ctx, model.Name)
+	err := clusterClient(r.client, model.Cluster).CreateRole(ctx, model.Name)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot create role",
-			"Create role query failed: "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot create role", err)
 		return
 	}
 
@@ -83,13 +82,13 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	receivedRoleName, err := r.client.GetRole(ctx, model.Name)
+	receivedRoleName, err := clusterClient(r.client, model.Cluster).GetRole(ctx, model.Name)
 	if err != nil {
 		handleNotFoundError(ctx, err, resp, "role", model.Name)
 		return
 	}
 
-	model = RoleResourceModel{Name: receivedRoleName, ID: types.StringValue(receivedRoleName)}
+	model = RoleResourceModel{Name: receivedRoleName, ID: types.StringValue(receivedRoleName), Cluster: model.Cluster}
 	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
 }
 
@@ -104,12 +103,9 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	err := r.client.RenameRole(ctx, stateModel.Name, planModel.Name)
+	err := clusterClient(r.client, planModel.Cluster).RenameRole(ctx, stateModel.Name, planModel.Name)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot rename role",
-			err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot rename role", err)
 		return
 	}
 
@@ -123,12 +119,9 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.DropRole(ctx, model.Name)
+	err := clusterClient(r.client, model.Cluster).DropRole(ctx, model.Name)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot delete table",
-			err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot delete role", err)
 		return
 	}
 }
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+var _ resource.Resource = &RowPolicyResource{}
+var _ resource.ResourceWithImportState = &RowPolicyResource{}
+
+func NewRowPolicyResource() resource.Resource {
+	return &RowPolicyResource{}
+}
+
+type RowPolicyResource struct {
+	client *chclient.ClickHouseClient
+}
+
+type RowPolicyResourceModel struct {
+	ID       types.String  `tfsdk:"id"`
+	Name     string        `tfsdk:"name"`
+	Database string        `tfsdk:"database"`
+	Table    string        `tfsdk:"table"`
+	Using    string        `tfsdk:"using"`
+	As       string        `tfsdk:"as"`
+	ApplyTo  *ApplyToModel `tfsdk:"apply_to"`
+	Cluster  types.String  `tfsdk:"cluster"`
+}
+
+func (r *RowPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_row_policy"
+}
+
+func (r *RowPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "ClickHouse row policy. See: https://clickhouse.com/docs/en/sql-reference/statements/create/row-policy",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Row policy name in `name ON database.table` format",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Row policy name in ClickHouse",
+				Required:            true,
+				Validators:          []validator.String{clickHouseIdentifierValidator},
+			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database of the table the policy is applied to",
+				Required:            true,
+				Validators:          []validator.String{clickHouseIdentifierValidator},
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"table": schema.StringAttribute{
+				MarkdownDescription: "Table the policy is applied to. `*` means all tables of the database",
+				Required:            true,
+				Validators:          []validator.String{grantEntityValidator{}},
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"using": schema.StringAttribute{
+				MarkdownDescription: "Filter expression. Rows for which the expression is false are not visible (`USING` clause)",
+				Required:            true,
+			},
+			"as": schema.StringAttribute{
+				MarkdownDescription: "How the policy is combined with other policies on the same table: " +
+					"`PERMISSIVE` policies are combined with OR, `RESTRICTIVE` ones with AND",
+				Optional:   true,
+				Computed:   true,
+				Default:    stringdefault.StaticString("PERMISSIVE"),
+				Validators: []validator.String{stringvalidator.OneOf("PERMISSIVE", "RESTRICTIVE")},
+			},
+			"apply_to": applyToAttribute("row policy"),
+			"cluster":  clusterAttribute(),
+		},
+	}
+}
+
+func (r *RowPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	client, err := configureClickHouseClient(ctx, req, resp)
+	if err != nil {
+		return
+	}
+	r.client = client
+}
+
+func (r *RowPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model RowPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy := model.toChClientRowPolicy()
+	err := clusterClient(r.client, model.Cluster).CreateRowPolicy(ctx, policy)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot create row policy", err)
+		return
+	}
+
+	model.ID = types.StringValue(policy.FullName())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *RowPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model RowPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := clusterClient(r.client, model.Cluster).GetRowPolicy(ctx, model.Name, model.Database, model.Table)
+	if err != nil {
+		handleNotFoundError(ctx, err, resp, "row policy", model.ID.ValueString())
+		return
+	}
+
+	model.ID = types.StringValue(policy.FullName())
+	model.Name = policy.Name
+	model.Database = policy.Database
+	model.Table = policy.Table
+	if !chclient.SameExpression(model.Using, policy.Using) {
+		model.Using = policy.Using
+	}
+	model.As = "PERMISSIVE"
+	if policy.Restrictive {
+		model.As = "RESTRICTIVE"
+	}
+	model.ApplyTo = fromChClientApplyTo(policy.ApplyTo)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *RowPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var stateModel RowPolicyResourceModel
+	var planModel RowPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy := planModel.toChClientRowPolicy()
+	err := clusterClient(r.client, planModel.Cluster).AlterRowPolicy(ctx, stateModel.Name, policy)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot alter row policy", err)
+		return
+	}
+
+	planModel.ID = types.StringValue(policy.FullName())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planModel)...)
+}
+
+func (r *RowPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model RowPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := clusterClient(r.client, model.Cluster).DropRowPolicy(ctx, model.Name, model.Database, model.Table)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot drop row policy", err)
+		return
+	}
+}
+
+func (r *RowPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, target, found := strings.Cut(req.ID, " ON ")
+	db, table, foundTable := strings.Cut(target, ".")
+
+	if !found || !foundTable {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID should be in `name ON database.table` format",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), db)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table"), table)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("using"), "")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("as"), "PERMISSIVE")...)
+}
+
+func (model RowPolicyResourceModel) toChClientRowPolicy() chclient.ClickHouseRowPolicy {
+	return chclient.ClickHouseRowPolicy{
+		Name:        model.Name,
+		Database:    model.Database,
+		Table:       model.Table,
+		Using:       model.Using,
+		Restrictive: model.As == "RESTRICTIVE",
+		ApplyTo:     model.ApplyTo.toChClientApplyTo(),
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRowPolicyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: chRowPolicyResource("mypolicy", "tenant_id = 1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_row_policy.test", "id", "mypolicy ON default.rp_events"),
+					resource.TestCheckResourceAttr("clickhouse_row_policy.test", "using", "tenant_id = 1"),
+					resource.TestCheckResourceAttr("clickhouse_row_policy.test", "as", "RESTRICTIVE"),
+				),
+			},
+			{
+				Config: chRowPolicyResource("mypolicy2", "tenant_id = 2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_row_policy.test", "id", "mypolicy2 ON default.rp_events"),
+					resource.TestCheckResourceAttr("clickhouse_row_policy.test", "using", "tenant_id = 2"),
+				),
+			},
+			{
+				Config:            chRowPolicyResource("mypolicy2", "tenant_id = 2"),
+				ResourceName:      "clickhouse_row_policy.test",
+				ImportState:       true,
+				ImportStateId:     "mypolicy2 ON default.rp_events",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func chRowPolicyResource(name, using string) string {
+	providerConfig := chProviderConfig()
+	resources := fmt.Sprintf(`
+resource "clickhouse_table" "test" {
+  database = "default"
+  name     = "rp_events"
+  engine   = "MergeTree"
+  order_by = ["tenant_id"]
+
+  columns = [
+    {
+      name = "tenant_id"
+      type = "UInt64"
+    },
+  ]
+}
+
+resource "clickhouse_role" "test" {
+  name = "rp_role"
+}
+
+resource "clickhouse_row_policy" "test" {
+  name     = %[1]q
+  database = clickhouse_table.test.database
+  table    = clickhouse_table.test.name
+  using    = %[2]q
+  as       = "RESTRICTIVE"
+
+  apply_to = {
+    names = [clickhouse_role.test.name]
+  }
+}
+`, name, using)
+	return providerConfig + resources
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+var _ resource.Resource = &SettingsProfileResource{}
+var _ resource.ResourceWithImportState = &SettingsProfileResource{}
+
+func NewSettingsProfileResource() resource.Resource {
+	return &SettingsProfileResource{}
+}
+
+type SettingsProfileResource struct {
+	client *chclient.ClickHouseClient
+}
+
+type SettingConstraintModel struct {
+	Name        string       `tfsdk:"name"`
+	Value       types.String `tfsdk:"value"`
+	Min         types.String `tfsdk:"min"`
+	Max         types.String `tfsdk:"max"`
+	Writability types.String `tfsdk:"writability"`
+}
+
+type SettingsProfileResourceModel struct {
+	ID       types.String             `tfsdk:"id"`
+	Name     string                   `tfsdk:"name"`
+	Inherit  []string                 `tfsdk:"inherit"`
+	Settings []SettingConstraintModel `tfsdk:"settings"`
+	ApplyTo  *ApplyToModel            `tfsdk:"apply_to"`
+	Cluster  types.String             `tfsdk:"cluster"`
+}
+
+func (r *SettingsProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_settings_profile"
+}
+
+func (r *SettingsProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "ClickHouse settings profile. " +
+			"See: https://clickhouse.com/docs/en/sql-reference/statements/create/settings-profile",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Settings profile name in ClickHouse",
+				Required:            true,
+				Validators:          []validator.String{clickHouseIdentifierValidator},
+			},
+			"inherit": schema.ListAttribute{
+				MarkdownDescription: "Names of settings profiles to inherit settings from (`INHERIT` elements). " +
+					"Settings of the profile override inherited ones",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"settings": schema.ListNestedAttribute{
+				MarkdownDescription: "Settings and their constraints. " +
+					"Values should be set in the form ClickHouse shows them in `system.settings_profile_elements`, " +
+					"e.g. `10000000000` rather than `10G`",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Setting name",
+							Required:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Setting value",
+							Optional:            true,
+						},
+						"min": schema.StringAttribute{
+							MarkdownDescription: "Minimal allowed value (`MIN` constraint)",
+							Optional:            true,
+						},
+						"max": schema.StringAttribute{
+							MarkdownDescription: "Maximal allowed value (`MAX` constraint)",
+							Optional:            true,
+						},
+						"writability": schema.StringAttribute{
+							MarkdownDescription: "Whether users can change the setting. " +
+								"One of `CONST`, `WRITABLE` and `CHANGEABLE_IN_READONLY`",
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("CONST", "WRITABLE", "CHANGEABLE_IN_READONLY"),
+							},
+						},
+					},
+				},
+			},
+			"apply_to": applyToAttribute("settings profile"),
+			"cluster":  clusterAttribute(),
+		},
+	}
+}
+
+func (r *SettingsProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	client, err := configureClickHouseClient(ctx, req, resp)
+	if err != nil {
+		return
+	}
+	r.client = client
+}
+
+func (r *SettingsProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model SettingsProfileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := clusterClient(r.client, model.Cluster).CreateSettingsProfile(ctx, model.toChClientSettingsProfile())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot create settings profile", err)
+		return
+	}
+
+	model.ID = types.StringValue(model.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SettingsProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model SettingsProfileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, err := clusterClient(r.client, model.Cluster).GetSettingsProfile(ctx, model.Name)
+	if err != nil {
+		handleNotFoundError(ctx, err, resp, "settings profile", model.Name)
+		return
+	}
+
+	received := fromChClientSettingsProfile(profile)
+	received.Cluster = model.Cluster
+	resp.Diagnostics.Append(resp.State.Set(ctx, &received)...)
+}
+
+func (r *SettingsProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var stateModel SettingsProfileResourceModel
+	var planModel SettingsProfileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := clusterClient(r.client, planModel.Cluster).AlterSettingsProfile(
+		ctx,
+		stateModel.Name,
+		planModel.toChClientSettingsProfile(),
+	)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot alter settings profile", err)
+		return
+	}
+
+	planModel.ID = types.StringValue(planModel.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planModel)...)
+}
+
+func (r *SettingsProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model SettingsProfileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := clusterClient(r.client, model.Cluster).DropSettingsProfile(ctx, model.Name)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Cannot drop settings profile", err)
+		return
+	}
+}
+
+func (r *SettingsProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (model SettingsProfileResourceModel) toChClientSettingsProfile() chclient.ClickHouseSettingsProfile {
+	settings := make([]chclient.ClickHouseSettingConstraint, 0, len(model.Settings))
+	for _, setting := range model.Settings {
+		settings = append(settings, chclient.ClickHouseSettingConstraint{
+			Name:        setting.Name,
+			Value:       setting.Value.ValueString(),
+			Min:         setting.Min.ValueString(),
+			Max:         setting.Max.ValueString(),
+			Writability: setting.Writability.ValueString(),
+		})
+	}
+
+	return chclient.ClickHouseSettingsProfile{
+		Name:     model.Name,
+		Inherit:  model.Inherit,
+		Settings: settings,
+		ApplyTo:  model.ApplyTo.toChClientApplyTo(),
+	}
+}
+
+func fromChClientSettingsProfile(profile chclient.ClickHouseSettingsProfile) SettingsProfileResourceModel {
+	var settings []SettingConstraintModel
+	for _, setting := range profile.Settings {
+		settings = append(settings, SettingConstraintModel{
+			Name:        setting.Name,
+			Value:       stringOrNull(setting.Value),
+			Min:         stringOrNull(setting.Min),
+			Max:         stringOrNull(setting.Max),
+			Writability: stringOrNull(setting.Writability),
+		})
+	}
+
+	return SettingsProfileResourceModel{
+		ID:       types.StringValue(profile.Name),
+		Name:     profile.Name,
+		Inherit:  profile.Inherit,
+		Settings: settings,
+		ApplyTo:  fromChClientApplyTo(profile.ApplyTo),
+	}
+}
+
+// stringOrNull converts empty strings received from ClickHouse to null values of optional attributes.
+func stringOrNull(v string) types.String {
+	if v == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(v)
+}
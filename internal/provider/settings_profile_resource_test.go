@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSettingsProfileResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: chSettingsProfileResource("myprofile", "10000000000"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_settings_profile.test", "id", "myprofile"),
+					resource.TestCheckResourceAttr("clickhouse_settings_profile.test", "settings.0.value", "10000000000"),
+					resource.TestCheckResourceAttr("clickhouse_settings_profile.test", "settings.1.writability", "CONST"),
+					resource.TestCheckResourceAttr("clickhouse_settings_profile.test", "apply_to.names.0", "profile_role"),
+				),
+			},
+			{
+				Config: chSettingsProfileResource("myprofile2", "20000000000"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_settings_profile.test", "id", "myprofile2"),
+					resource.TestCheckResourceAttr("clickhouse_settings_profile.test", "settings.0.value", "20000000000"),
+				),
+			},
+			{
+				Config:            chSettingsProfileResource("myprofile2", "20000000000"),
+				ResourceName:      "clickhouse_settings_profile.test",
+				ImportState:       true,
+				ImportStateId:     "myprofile2",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func chSettingsProfileResource(name, maxMemoryUsage string) string {
+	providerConfig := chProviderConfig()
+	resources := fmt.Sprintf(`
+resource "clickhouse_role" "test" {
+  name = "profile_role"
+}
+
+resource "clickhouse_settings_profile" "test" {
+  name    = %[1]q
+  inherit = ["default"]
+
+  settings = [
+    {
+      name  = "max_memory_usage"
+      value = %[2]q
+      min   = "1000000"
+    },
+    {
+      name        = "max_threads"
+      value       = "4"
+      writability = "CONST"
+    },
+  ]
+
+  apply_to = {
+    names = [clickhouse_role.test.name]
+  }
+}
+`, name, maxMemoryUsage)
+	return providerConfig + resources
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+type ConstraintModel struct {
+	Name       string `tfsdk:"name"`
+	Type       string `tfsdk:"type"`
+	Expression string `tfsdk:"expression"`
+}
+
+// constraintsAttribute describes constraints of a table.
+func constraintsAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Constraints of the table. `CHECK` constraints validate inserted rows, " +
+			"`ASSUME` constraints are used by the optimizer only. " +
+			"See: https://clickhouse.com/docs/en/sql-reference/statements/create/table#constraints",
+		Optional:   true,
+		Validators: []validator.List{listvalidator.SizeAtLeast(1)},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Constraint name",
+					Required:            true,
+					Validators:          []validator.String{clickHouseIdentifierValidator},
+				},
+				"type": schema.StringAttribute{
+					MarkdownDescription: "Constraint type: `CHECK` or `ASSUME`",
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString("CHECK"),
+					Validators:          []validator.String{stringvalidator.OneOf("CHECK", "ASSUME")},
+				},
+				"expression": schema.StringAttribute{
+					MarkdownDescription: "Boolean expression of the constraint, e.g. `amount >= 0`",
+					Required:            true,
+					Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+				},
+			},
+		},
+	}
+}
+
+func (constraint ConstraintModel) toChClientConstraint() chclient.ClickHouseConstraint {
+	return chclient.ClickHouseConstraint{
+		Name:       constraint.Name,
+		Type:       constraint.Type,
+		Expression: constraint.Expression,
+	}
+}
+
+func toChClientConstraints(constraints []ConstraintModel) []chclient.ClickHouseConstraint {
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	result := make([]chclient.ClickHouseConstraint, 0, len(constraints))
+	for _, constraint := range constraints {
+		result = append(result, constraint.toChClientConstraint())
+	}
+	return result
+}
+
+func fromChClientConstraints(constraints []chclient.ClickHouseConstraint) []ConstraintModel {
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	result := make([]ConstraintModel, 0, len(constraints))
+	for _, constraint := range constraints {
+		result = append(result, ConstraintModel{
+			Name:       constraint.Name,
+			Type:       constraint.Type,
+			Expression: constraint.Expression,
+		})
+	}
+	return result
+}
+
+func constraintName(constraint ConstraintModel) string {
+	return constraint.Name
+}
+
+// sameConstraint reports whether the constraints have the same type and expression.
+func sameConstraint(configured, actual ConstraintModel) bool {
+	return configured.toChClientConstraint().Same(actual.toChClientConstraint())
+}
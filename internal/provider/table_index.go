@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+type IndexModel struct {
+	Name        string `tfsdk:"name"`
+	Expression  string `tfsdk:"expression"`
+	Type        string `tfsdk:"type"`
+	Granularity int64  `tfsdk:"granularity"`
+}
+
+// indexesAttribute describes data skipping indexes of a table.
+func indexesAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Data skipping indexes of the table. " +
+			"See: https://clickhouse.com/docs/en/engines/table-engines/mergetree-family/mergetree#table_engine-mergetree-data_skipping-indexes",
+		Optional:   true,
+		Validators: []validator.List{listvalidator.SizeAtLeast(1)},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Index name",
+					Required:            true,
+					Validators:          []validator.String{clickHouseIdentifierValidator},
+				},
+				"expression": schema.StringAttribute{
+					MarkdownDescription: "Expression to build the index on, e.g. `user_id` or `lower(name)`",
+					Required:            true,
+					Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+				},
+				"type": schema.StringAttribute{
+					MarkdownDescription: "Index type with parameters, e.g. `minmax`, `set(100)` or `bloom_filter(0.01)`",
+					Required:            true,
+					Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+				},
+				"granularity": schema.Int64Attribute{
+					MarkdownDescription: "Number of granules in one index block",
+					Optional:            true,
+					Computed:            true,
+					Default:             int64default.StaticInt64(1),
+					Validators:          []validator.Int64{int64validator.AtLeast(1)},
+				},
+			},
+		},
+	}
+}
+
+func (index IndexModel) toChClientIndex() chclient.ClickHouseIndex {
+	return chclient.ClickHouseIndex{
+		Name:        index.Name,
+		Expression:  index.Expression,
+		Type:        index.Type,
+		Granularity: uint64(index.Granularity),
+	}
+}
+
+func toChClientIndexes(indexes []IndexModel) []chclient.ClickHouseIndex {
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	result := make([]chclient.ClickHouseIndex, 0, len(indexes))
+	for _, index := range indexes {
+		result = append(result, index.toChClientIndex())
+	}
+	return result
+}
+
+func fromChClientIndexes(indexes []chclient.ClickHouseIndex) []IndexModel {
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	result := make([]IndexModel, 0, len(indexes))
+	for _, index := range indexes {
+		result = append(result, IndexModel{
+			Name:        index.Name,
+			Expression:  index.Expression,
+			Type:        index.Type,
+			Granularity: int64(index.Granularity),
+		})
+	}
+	return result
+}
+
+func indexName(index IndexModel) string {
+	return index.Name
+}
+
+// sameIndex reports whether the indexes have the same expression, type and granularity.
+func sameIndex(configured, actual IndexModel) bool {
+	return configured.toChClientIndex().Same(actual.toChClientIndex())
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+type ProjectionModel struct {
+	Name  string `tfsdk:"name"`
+	Query string `tfsdk:"query"`
+}
+
+// projectionsAttribute describes projections of a table.
+func projectionsAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Projections of the table. " +
+			"See: https://clickhouse.com/docs/en/sql-reference/statements/alter/projection",
+		Optional:   true,
+		Validators: []validator.List{listvalidator.SizeAtLeast(1)},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Projection name",
+					Required:            true,
+					Validators:          []validator.String{clickHouseIdentifierValidator},
+				},
+				"query": schema.StringAttribute{
+					MarkdownDescription: "SELECT body of the projection without parentheses, e.g. `SELECT * ORDER BY user_id`",
+					Required:            true,
+					Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+				},
+			},
+		},
+	}
+}
+
+func toChClientProjections(projections []ProjectionModel) []chclient.ClickHouseProjection {
+	if len(projections) == 0 {
+		return nil
+	}
+
+	result := make([]chclient.ClickHouseProjection, 0, len(projections))
+	for _, projection := range projections {
+		result = append(result, chclient.ClickHouseProjection{Name: projection.Name, Query: projection.Query})
+	}
+	return result
+}
+
+func fromChClientProjections(projections []chclient.ClickHouseProjection) []ProjectionModel {
+	if len(projections) == 0 {
+		return nil
+	}
+
+	result := make([]ProjectionModel, 0, len(projections))
+	for _, projection := range projections {
+		result = append(result, ProjectionModel{Name: projection.Name, Query: projection.Query})
+	}
+	return result
+}
+
+func projectionName(projection ProjectionModel) string {
+	return projection.Name
+}
+
+// sameProjection reports whether the projections have the same query.
+func sameProjection(configured, actual ProjectionModel) bool {
+	return chclient.SameExpression(configured.Query, actual.Query)
+}
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -18,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
@@ -28,6 +28,7 @@ import (
 
 var _ resource.Resource = &TableResource{}
 var _ resource.ResourceWithImportState = &TableResource{}
+var _ resource.ResourceWithModifyPlan = &TableResource{}
 var enginesRequiresReplaceIfSettingsChanges = []string{"RabbitMQ"}
 
 func NewTableResource() resource.Resource {
@@ -39,10 +40,16 @@ type TableResource struct {
 }
 
 type ColumnModel struct {
-	Name     string `tfsdk:"name"`
-	Type     string `tfsdk:"type"`
-	Nullable bool   `tfsdk:"nullable"`
-	Comment  string `tfsdk:"comment"`
+	Name              string `tfsdk:"name"`
+	Type              string `tfsdk:"type"`
+	Nullable          bool   `tfsdk:"nullable"`
+	Comment           string `tfsdk:"comment"`
+	DefaultKind       string `tfsdk:"default_kind"`
+	DefaultExpression string `tfsdk:"default_expression"`
+	Codec             string `tfsdk:"codec"`
+	TTL               string `tfsdk:"ttl"`
+
+	PreviousName types.String `tfsdk:"previous_name"`
 }
 
 type TableResourceModel struct {
@@ -59,9 +66,24 @@ type TableResourceModel struct {
 	PartitionBy types.String `tfsdk:"partition_by"`
 	OrderBy     []string     `tfsdk:"order_by"`
 	PrimaryKey  types.List   `tfsdk:"primary_key"`
+	SampleBy    types.String `tfsdk:"sample_by"`
 	Settings    types.Map    `tfsdk:"settings"`
 
-	Comment string `tfsdk:"comment"`
+	TTL                    []TTLRuleModel `tfsdk:"ttl"`
+	MaterializeTTLOnChange types.Bool     `tfsdk:"materialize_ttl_on_change"`
+
+	Indexes                    []IndexModel `tfsdk:"indexes"`
+	MaterializeIndexesOnChange types.Bool   `tfsdk:"materialize_indexes_on_change"`
+
+	Projections                    []ProjectionModel `tfsdk:"projections"`
+	MaterializeProjectionsOnChange types.Bool        `tfsdk:"materialize_projections_on_change"`
+
+	Constraints []ConstraintModel `tfsdk:"constraints"`
+
+	AllowDropColumns types.Bool `tfsdk:"allow_drop_columns"`
+
+	Comment string       `tfsdk:"comment"`
+	Cluster types.String `tfsdk:"cluster"`
 }
 
 func (r *TableResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -110,12 +132,14 @@ func (r *TableResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
 			"engine_parameters": schema.ListAttribute{
-				Optional:            true,
-				Computed:            true,
-				ElementType:         types.StringType,
-				MarkdownDescription: "Parameters for engine. Will be transformed to `engine(param1, param2, ...)`",
-				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, make([]attr.Value, 0))),
-				PlanModifiers:       []planmodifier.List{partitionByPlanModifier{}, listplanmodifier.RequiresReplace()},
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Parameters for engine. Will be transformed to `engine(param1, param2, ...)`. " +
+					"Column names, numbers and function calls are used as they are, other values are passed as string literals, " +
+					"e.g. `[\"/clickhouse/tables/{shard}/t\", \"{replica}\"]`",
+				Default:       listdefault.StaticValue(types.ListValueMust(types.StringType, make([]attr.Value, 0))),
+				PlanModifiers: []planmodifier.List{partitionByPlanModifier{}, listplanmodifier.RequiresReplace()},
 			},
 			"partition_by": schema.StringAttribute{
 				Optional:            true,
@@ -128,7 +152,7 @@ func (r *TableResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:            true,
 				Computed:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "Values to fill `ORDER BY` clause.",
+				MarkdownDescription: "Columns or expressions to fill `ORDER BY` clause, e.g. `[\"user_id\", \"toStartOfHour(ts)\"]`.",
 				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, make([]attr.Value, 0))),
 				PlanModifiers:       []planmodifier.List{listplanmodifier.RequiresReplace()},
 			},
@@ -136,12 +160,19 @@ func (r *TableResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:            true,
 				Computed:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "Values to fill `PRIMARY KEY` clause.",
+				MarkdownDescription: "Columns or expressions to fill `PRIMARY KEY` clause.",
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
 					listplanmodifier.RequiresReplace(),
 				},
 			},
+			"sample_by": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Expression to fill `SAMPLE BY` clause, e.g. `cityHash64(user_id)`. It must be a part of the primary key.",
+				Default:             stringdefault.StaticString(""),
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
 			"settings": schema.MapAttribute{
 				Optional:            true,
 				Computed:            true,
@@ -166,6 +197,38 @@ func (r *TableResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					),
 				},
 			},
+			"ttl": ttlAttribute(),
+			"materialize_ttl_on_change": schema.BoolAttribute{
+				MarkdownDescription: "Whether to apply changed TTL rules to existing data with `MATERIALIZE TTL`. " +
+					"Otherwise, new rules are applied to new parts and merges only. Materialization rewrites data and may take a while",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"indexes": indexesAttribute(),
+			"materialize_indexes_on_change": schema.BoolAttribute{
+				MarkdownDescription: "Whether to build added or changed indexes for existing data with `MATERIALIZE INDEX`. " +
+					"Otherwise, indexes are built for new parts only",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"projections": projectionsAttribute(),
+			"materialize_projections_on_change": schema.BoolAttribute{
+				MarkdownDescription: "Whether to build added or changed projections for existing data with `MATERIALIZE PROJECTION`. " +
+					"Otherwise, projections are built for new parts only",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"constraints": constraintsAttribute(),
+			"allow_drop_columns": schema.BoolAttribute{
+				MarkdownDescription: "Whether columns removed from `columns` are dropped with their data. " +
+					"Otherwise, removing a column is an error",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"columns": schema.ListNestedAttribute{
 				Required:            true,
 				MarkdownDescription: "Columns of ClickHouse table",
@@ -177,41 +240,11 @@ func (r *TableResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							Validators:          []validator.String{clickHouseIdentifierValidator},
 						},
 						"type": schema.StringAttribute{
-							Required:            true,
-							MarkdownDescription: "Column type. See: https://clickhouse.com/docs/en/sql-reference/data-types",
-							Validators: []validator.String{
-								stringvalidator.OneOf(
-									"UInt8",
-									"UInt16",
-									"UInt32",
-									"UInt64",
-									"UInt128",
-									"UInt256",
-									"Int8",
-									"Int16",
-									"Int32",
-									"Int64",
-									"Int128",
-									"Int256",
-									"Float32",
-									"Float64",
-									"Decimal",
-									"Boolean",
-									"String",
-									"FixedString",
-									"Date",
-									"Date32",
-									"DateTime",
-									"DateTime64",
-									"JSON",
-									"UUID",
-									"LowCardinality",
-									"SimpleAggregateFunction",
-									"AggregateFunction",
-									"IPv4",
-									"IPv6",
-								),
-							},
+							Required: true,
+							MarkdownDescription: "Column type, e.g. `Decimal(18, 4)` or `Array(String)`. " +
+								"Aliases like `INT` or `BOOL` are accepted. `Nullable(T)` is the same as `T` with `nullable = true`. " +
+								"See: https://clickhouse.com/docs/en/sql-reference/data-types",
+							Validators: []validator.String{columnTypeValidator{}},
 						},
 						"comment": schema.StringAttribute{
 							MarkdownDescription: "Comment for a column",
@@ -225,10 +258,51 @@ func (r *TableResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							Computed:            true,
 							Default:             booldefault.StaticBool(false),
 						},
+						"default_kind": schema.StringAttribute{
+							MarkdownDescription: "Kind of the column default value: `DEFAULT`, `MATERIALIZED`, `ALIAS` or `EPHEMERAL`. " +
+								"Empty for ordinary columns. " +
+								"See: https://clickhouse.com/docs/en/sql-reference/statements/create/table#default_values",
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(""),
+							Validators: []validator.String{
+								stringvalidator.OneOf("", "DEFAULT", "MATERIALIZED", "ALIAS", "EPHEMERAL"),
+							},
+						},
+						"default_expression": schema.StringAttribute{
+							MarkdownDescription: "Expression for the column default value, e.g. `toDate(ts)`. " +
+								"Required unless `default_kind` is empty or `EPHEMERAL`",
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(""),
+						},
+						"codec": schema.StringAttribute{
+							MarkdownDescription: "Compression codecs of the column without the `CODEC` keyword, e.g. `Delta, ZSTD(3)`. " +
+								"See: https://clickhouse.com/docs/en/sql-reference/statements/create/table#column_compression_codec",
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(""),
+						},
+						"ttl": schema.StringAttribute{
+							MarkdownDescription: "TTL expression of the column, e.g. `ts + INTERVAL 1 MONTH`. " +
+								"Expired values are replaced with the column default. " +
+								"See: https://clickhouse.com/docs/en/engines/table-engines/mergetree-family/mergetree#mergetree-column-ttl",
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(""),
+						},
+						"previous_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the column to rename with `RENAME COLUMN`. " +
+								"If the table has no column with this name, the column is added. " +
+								"It is safe to keep the attribute after the column is renamed",
+							Optional:   true,
+							Validators: []validator.String{clickHouseIdentifierValidator},
+						},
 					},
 				},
 				Validators: []validator.List{listvalidator.SizeAtLeast(1)},
 			},
+			"cluster": clusterAttribute(),
 		},
 	}
 }
@@ -255,20 +329,15 @@ func (r *TableResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	err := r.client.CreateTable(ctx, table)
+	client := clusterClient(r.client, tableModel.Cluster)
+	err := client.CreateTable(ctx, table)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot create table",
-			"Create table query failed: "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot create table", err)
 		return
 	}
-	createdTableInfo, err := r.client.GetTable(ctx, table.Database, table.Name)
+	createdTableInfo, err := client.GetTable(ctx, table.Database, table.Name)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot read table info",
-			"Create table query failed: "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot read table info", err)
 		return
 	}
 	createdTableModel, diags := fromChClientTableInfo(ctx, createdTableInfo)
@@ -276,6 +345,17 @@ func (r *TableResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	createdTableModel.Cluster = tableModel.Cluster
+	createdTableModel.Columns = keepConfiguredColumns(tableModel.Columns, createdTableModel.Columns)
+	resp.Diagnostics.Append(createdTableModel.keepConfiguredKeys(ctx, tableModel)...)
+	createdTableModel.TTL = keepConfiguredTTL(tableModel.TTL, createdTableModel.TTL)
+	createdTableModel.MaterializeTTLOnChange = tableModel.MaterializeTTLOnChange
+	createdTableModel.Indexes = keepConfiguredElements(tableModel.Indexes, createdTableModel.Indexes, indexName, sameIndex)
+	createdTableModel.MaterializeIndexesOnChange = tableModel.MaterializeIndexesOnChange
+	createdTableModel.Projections = keepConfiguredElements(tableModel.Projections, createdTableModel.Projections, projectionName, sameProjection)
+	createdTableModel.MaterializeProjectionsOnChange = tableModel.MaterializeProjectionsOnChange
+	createdTableModel.Constraints = keepConfiguredElements(tableModel.Constraints, createdTableModel.Constraints, constraintName, sameConstraint)
+	createdTableModel.AllowDropColumns = tableModel.AllowDropColumns
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, createdTableModel)...)
 }
@@ -288,7 +368,7 @@ func (r *TableResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	receivedTableInfo, err := r.client.GetTable(ctx, stateTableModel.Database, stateTableModel.Name)
+	receivedTableInfo, err := clusterClient(r.client, stateTableModel.Cluster).GetTable(ctx, stateTableModel.Database, stateTableModel.Name)
 	if err != nil {
 		handleNotFoundError(ctx, err, resp, "table", stateTableModel.FullName.ValueString())
 		return
@@ -299,6 +379,17 @@ func (r *TableResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	table.Cluster = stateTableModel.Cluster
+	table.Columns = keepConfiguredColumns(stateTableModel.Columns, table.Columns)
+	resp.Diagnostics.Append(table.keepConfiguredKeys(ctx, stateTableModel)...)
+	table.TTL = keepConfiguredTTL(stateTableModel.TTL, table.TTL)
+	table.MaterializeTTLOnChange = types.BoolValue(stateTableModel.MaterializeTTLOnChange.ValueBool())
+	table.Indexes = keepConfiguredElements(stateTableModel.Indexes, table.Indexes, indexName, sameIndex)
+	table.MaterializeIndexesOnChange = types.BoolValue(stateTableModel.MaterializeIndexesOnChange.ValueBool())
+	table.Projections = keepConfiguredElements(stateTableModel.Projections, table.Projections, projectionName, sameProjection)
+	table.MaterializeProjectionsOnChange = types.BoolValue(stateTableModel.MaterializeProjectionsOnChange.ValueBool())
+	table.Constraints = keepConfiguredElements(stateTableModel.Constraints, table.Constraints, constraintName, sameConstraint)
+	table.AllowDropColumns = types.BoolValue(stateTableModel.AllowDropColumns.ValueBool())
 	resp.Diagnostics.Append(resp.State.Set(ctx, table)...)
 }
 
@@ -327,21 +418,16 @@ func (r *TableResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	err := r.client.AlterTable(ctx, stateTable.Name, table)
+	client := clusterClient(r.client, planTable.Cluster)
+	err := client.AlterTable(ctx, stateTable.Name, table)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot alter table",
-			err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot alter table", err)
 		return
 	}
 
-	updatedTableInfo, err := r.client.GetTable(ctx, table.Database, table.Name)
+	updatedTableInfo, err := client.GetTable(ctx, table.Database, table.Name)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot read table info",
-			"Update table query failed: "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot read table info", err)
 		return
 	}
 
@@ -350,10 +436,116 @@ func (r *TableResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	updatedTableModel.Cluster = planTable.Cluster
+	updatedTableModel.Columns = keepConfiguredColumns(planTable.Columns, updatedTableModel.Columns)
+	resp.Diagnostics.Append(updatedTableModel.keepConfiguredKeys(ctx, planTable)...)
+	updatedTableModel.TTL = keepConfiguredTTL(planTable.TTL, updatedTableModel.TTL)
+	updatedTableModel.MaterializeTTLOnChange = planTable.MaterializeTTLOnChange
+	updatedTableModel.Indexes = keepConfiguredElements(planTable.Indexes, updatedTableModel.Indexes, indexName, sameIndex)
+	updatedTableModel.MaterializeIndexesOnChange = planTable.MaterializeIndexesOnChange
+	updatedTableModel.Projections = keepConfiguredElements(planTable.Projections, updatedTableModel.Projections, projectionName, sameProjection)
+	updatedTableModel.MaterializeProjectionsOnChange = planTable.MaterializeProjectionsOnChange
+	updatedTableModel.Constraints = keepConfiguredElements(planTable.Constraints, updatedTableModel.Constraints, constraintName, sameConstraint)
+	updatedTableModel.AllowDropColumns = planTable.AllowDropColumns
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, updatedTableModel)...)
 }
 
+// ModifyPlan reports features which are not supported by the ClickHouse server
+// and columns which would be dropped with their data by the update.
+func (r *TableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.requireCapabilities(ctx, req.Plan, &resp.Diagnostics)
+	if req.State.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var stateColumns []ColumnModel
+	var planColumns types.List
+	var allowDropColumns types.Bool
+	var fullName types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("columns"), &stateColumns)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("full_name"), &fullName)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("columns"), &planColumns)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_drop_columns"), &allowDropColumns)...)
+	if resp.Diagnostics.HasError() || planColumns.IsUnknown() {
+		return
+	}
+
+	// Values of planned columns may be unknown, so only known names are taken into account.
+	keptColumns := make(map[string]bool)
+	for _, element := range planColumns.Elements() {
+		column, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+		for _, attrName := range []string{"name", "previous_name"} {
+			value, ok := column.Attributes()[attrName].(types.String)
+			if !ok || value.IsUnknown() {
+				return
+			}
+			if !value.IsNull() {
+				keptColumns[value.ValueString()] = true
+			}
+		}
+	}
+
+	var droppedColumns []string
+	for _, col := range stateColumns {
+		if !keptColumns[col.Name] {
+			droppedColumns = append(droppedColumns, col.Name)
+		}
+	}
+	if len(droppedColumns) == 0 {
+		return
+	}
+
+	if allowDropColumns.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("columns"),
+			"Columns will be dropped",
+			"Columns "+strings.Join(droppedColumns, ", ")+" of table "+fullName.ValueString()+
+				" will be dropped with their data",
+		)
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("columns"),
+		"Columns would lose data",
+		"Columns "+strings.Join(droppedColumns, ", ")+" of table "+fullName.ValueString()+
+			" are missing from the configuration and would be dropped with their data. "+
+			"Set `previous_name` of a column to rename it or `allow_drop_columns = true` to drop the columns",
+	)
+}
+
+// requireCapabilities reports projections, EPHEMERAL columns and ASSUME constraints
+// if the ClickHouse server does not support them.
+func (r *TableResource) requireCapabilities(ctx context.Context, plan tfsdk.Plan, diags *diag.Diagnostics) {
+	var columns, projections, constraints types.List
+	diags.Append(plan.GetAttribute(ctx, path.Root("columns"), &columns)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("projections"), &projections)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("constraints"), &constraints)...)
+	if diags.HasError() {
+		return
+	}
+
+	if len(projections.Elements()) > 0 {
+		requireCapability(diags, r.client, chclient.CapabilityProjections, path.Root("projections"))
+	}
+	for _, i := range elementsWithValue(columns, "default_kind", "EPHEMERAL") {
+		attribute := path.Root("columns").AtListIndex(i).AtName("default_kind")
+		requireCapability(diags, r.client, chclient.CapabilityEphemeralColumns, attribute)
+	}
+	for _, i := range elementsWithValue(constraints, "type", "ASSUME") {
+		attribute := path.Root("constraints").AtListIndex(i).AtName("type")
+		requireCapability(diags, r.client, chclient.CapabilityAssumeConstraints, attribute)
+	}
+}
+
 func (r *TableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var model TableResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
@@ -372,12 +564,9 @@ func (r *TableResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		checkIfTableEmpty = false
 	}
 
-	err := r.client.DropTable(ctx, table, checkIfTableEmpty)
+	err := clusterClient(r.client, model.Cluster).DropTable(ctx, table, checkIfTableEmpty)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot delete table",
-			err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot delete table", err)
 		return
 	}
 }
@@ -407,11 +596,29 @@ func toChClientTable(ctx context.Context, table TableResourceModel) (chclient.Cl
 
 	cols := make([]chclient.ClickHouseColumn, 0, len(table.Columns))
 	for _, col := range table.Columns {
+		colType, err := chclient.ParseColumnType(col.Type)
+		if err != nil {
+			diags.AddAttributeError(path.Root("columns"), "Invalid column type", err.Error())
+			continue
+		}
+		if (col.DefaultKind == "") != (col.DefaultExpression == "") && col.DefaultKind != "EPHEMERAL" {
+			diags.AddAttributeError(
+				path.Root("columns"),
+				"Invalid column default",
+				"Column "+col.Name+": default_kind and default_expression should be set together",
+			)
+			continue
+		}
 		cols = append(cols, chclient.ClickHouseColumn{
-			Name:     col.Name,
-			Type:     col.Type,
-			Comment:  col.Comment,
-			Nullable: col.Nullable,
+			Name:              col.Name,
+			Type:              colType.Unwrap().String(),
+			Comment:           col.Comment,
+			Nullable:          col.Nullable || colType.IsNullable(),
+			DefaultKind:       col.DefaultKind,
+			DefaultExpression: col.DefaultExpression,
+			Codec:             col.Codec,
+			TTL:               col.TTL,
+			PreviousName:      col.PreviousName.ValueString(),
 		})
 	}
 
@@ -447,8 +654,18 @@ func toChClientTable(ctx context.Context, table TableResourceModel) (chclient.Cl
 		PartitionBy:   table.PartitionBy.ValueString(),
 		OrderBy:       table.OrderBy,
 		PrimaryKeyArr: pk,
+		SampleBy:      table.SampleBy.ValueString(),
 		Settings:      settings,
 		Columns:       cols,
+		TTL:           toChClientTTL(table.TTL),
+		Indexes:       toChClientIndexes(table.Indexes),
+		Projections:   toChClientProjections(table.Projections),
+		Constraints:   toChClientConstraints(table.Constraints),
+
+		MaterializeTTL:         table.MaterializeTTLOnChange.ValueBool(),
+		MaterializeIndexes:     table.MaterializeIndexesOnChange.ValueBool(),
+		MaterializeProjections: table.MaterializeProjectionsOnChange.ValueBool(),
+		AllowDropColumns:       table.AllowDropColumns.ValueBool(),
 	}, diags
 }
 
@@ -457,10 +674,14 @@ func fromChClientTableInfo(ctx context.Context, table chclient.ClickHouseTableFu
 	cols := make([]ColumnModel, 0, len(table.Columns))
 	for _, col := range table.Columns {
 		cols = append(cols, ColumnModel{
-			Name:     col.Name,
-			Type:     col.Type,
-			Comment:  col.Comment,
-			Nullable: col.Nullable,
+			Name:              col.Name,
+			Type:              col.Type,
+			Comment:           col.Comment,
+			Nullable:          col.Nullable,
+			DefaultKind:       col.DefaultKind,
+			DefaultExpression: col.DefaultExpression,
+			Codec:             col.Codec,
+			TTL:               col.TTL,
 		})
 	}
 
@@ -484,19 +705,151 @@ func fromChClientTableInfo(ctx context.Context, table chclient.ClickHouseTableFu
 		PartitionBy:      types.StringValue(table.PartitionBy),
 		OrderBy:          table.OrderBy,
 		PrimaryKey:       pk,
+		SampleBy:         types.StringValue(table.SampleBy),
 		Settings:         settings,
 		Columns:          cols,
+		TTL:              fromChClientTTL(table.TTL),
+		Indexes:          fromChClientIndexes(table.Indexes),
+		Projections:      fromChClientProjections(table.Projections),
+		Constraints:      fromChClientConstraints(table.Constraints),
 	}, diags
 }
 
+// keepConfiguredColumns keeps column types, default expressions, codecs and TTLs as they are written in the configuration
+// if ClickHouse reports the same ones in another form, e.g. `Int32` for `INT`.
+func keepConfiguredColumns(configured, actual []ColumnModel) []ColumnModel {
+	actual = foldNestedColumns(configured, actual)
+
+	configuredByName := make(map[string]ColumnModel, len(configured))
+	for _, col := range configured {
+		configuredByName[col.Name] = col
+	}
+
+	result := make([]ColumnModel, 0, len(actual))
+	for _, col := range actual {
+		conf, ok := configuredByName[col.Name]
+		if ok && chclient.SameColumnType(conf.fullType(), col.fullType()) {
+			col.Type = conf.Type
+			col.Nullable = conf.Nullable
+		}
+		if ok && conf.DefaultKind == col.DefaultKind &&
+			(chclient.SameExpression(conf.DefaultExpression, col.DefaultExpression) || (conf.DefaultKind == "EPHEMERAL" && conf.DefaultExpression == "")) {
+			col.DefaultExpression = conf.DefaultExpression
+		}
+		if ok && chclient.SameCodec(conf.Codec, col.Codec) {
+			col.Codec = conf.Codec
+		}
+		if ok && chclient.SameExpression(conf.TTL, col.TTL) {
+			col.TTL = conf.TTL
+		}
+		if ok {
+			col.PreviousName = conf.PreviousName
+		}
+		result = append(result, col)
+	}
+
+	return result
+}
+
+// foldNestedColumns replaces columns `n.a Array(T)`, which ClickHouse shows for a configured column `n Nested(a T)`
+// when flatten_nested is enabled, with the `Nested` column itself.
+func foldNestedColumns(configured, actual []ColumnModel) []ColumnModel {
+	nested := make(map[string]bool)
+	for _, col := range configured {
+		if t, err := chclient.ParseColumnType(col.Type); err == nil && t.Name == "Nested" {
+			nested[col.Name] = true
+		}
+	}
+	if len(nested) == 0 {
+		return actual
+	}
+
+	result := make([]ColumnModel, 0, len(actual))
+	foldedTypes := make(map[int]*chclient.ColumnType)
+	foldedIndexes := make(map[string]int)
+	for _, col := range actual {
+		name, element, found := strings.Cut(col.Name, ".")
+		t, err := chclient.ParseColumnType(col.Type)
+		if !found || !nested[name] || err != nil || t.Name != "Array" || len(t.Args) != 1 || t.Args[0].Type == nil {
+			result = append(result, col)
+			continue
+		}
+
+		i, ok := foldedIndexes[name]
+		if !ok {
+			i = len(result)
+			foldedIndexes[name] = i
+			foldedTypes[i] = &chclient.ColumnType{Name: "Nested"}
+			col.Name = name
+			result = append(result, col)
+		}
+		foldedTypes[i].Args = append(foldedTypes[i].Args, chclient.ColumnTypeArg{Name: element, Type: t.Args[0].Type})
+	}
+	for i, t := range foldedTypes {
+		result[i].Type = t.String()
+	}
+
+	return result
+}
+
+// keepConfiguredElements keeps named table elements, e.g. indexes, as they are written in the configuration
+// if ClickHouse reports the same ones in another form.
+func keepConfiguredElements[T any](configured, actual []T, name func(T) string, same func(configured, actual T) bool) []T {
+	configuredByName := make(map[string]T, len(configured))
+	for _, element := range configured {
+		configuredByName[name(element)] = element
+	}
+
+	var result []T
+	for _, element := range actual {
+		conf, ok := configuredByName[name(element)]
+		if ok && same(conf, element) {
+			element = conf
+		}
+		result = append(result, element)
+	}
+	return result
+}
+
+// keepConfiguredKeys keeps partition, sorting, primary and sampling keys as they are written in the configuration
+// if ClickHouse reports the same expressions in another form, e.g. `toIntervalDay(1)` for `INTERVAL 1 DAY`.
+func (model *TableResourceModel) keepConfiguredKeys(ctx context.Context, configured TableResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.PartitionBy = keepConfiguredExpression(configured.PartitionBy, model.PartitionBy)
+	model.SampleBy = keepConfiguredExpression(configured.SampleBy, model.SampleBy)
+	if chclient.SameExpressions(configured.OrderBy, model.OrderBy) {
+		model.OrderBy = configured.OrderBy
+	}
+
+	if configured.PrimaryKey.IsNull() || configured.PrimaryKey.IsUnknown() {
+		return diags
+	}
+	var configuredPK, actualPK []string
+	diags.Append(configured.PrimaryKey.ElementsAs(ctx, &configuredPK, false)...)
+	diags.Append(model.PrimaryKey.ElementsAs(ctx, &actualPK, false)...)
+	if !diags.HasError() && chclient.SameExpressions(configuredPK, actualPK) {
+		model.PrimaryKey = configured.PrimaryKey
+	}
+
+	return diags
+}
+
+func keepConfiguredExpression(configured, actual types.String) types.String {
+	if configured.IsNull() || configured.IsUnknown() || !chclient.SameExpression(configured.ValueString(), actual.ValueString()) {
+		return actual
+	}
+	return configured
+}
+
+func (col ColumnModel) fullType() string {
+	return chclient.ClickHouseColumn{Type: col.Type, Nullable: col.Nullable}.FullType()
+}
+
 func handleNotFoundError(ctx context.Context, err error, resp *resource.ReadResponse, entity string, name string) {
-	var notFoundError *chclient.NotFoundError
-	ok := errors.As(err, &notFoundError)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Cannot find "+entity,
-			err.Error(),
-		)
+	if !chclient.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "Cannot read "+entity, err)
+		return
 	}
 
 	resp.Diagnostics.AddWarning(
@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -47,8 +51,159 @@ resource "clickhouse_table" "test" {
   columns = [
     {name = "date", type = "Date"},
     {name = "data", type = "Float64", nullable = true},
+    {name = "amount", type = "Decimal(18, 4)"},
+    {name = "tags", type = "Array(LowCardinality(String))"},
   ]
 }
 `, name)
 	return providerConfig + resources
 }
+
+func TestKeepConfiguredColumns(t *testing.T) {
+	configured := []ColumnModel{
+		{Name: "id", Type: "INT"},
+		{Name: "note", Type: "Nullable(String)"},
+		{Name: "amount", Type: "Decimal(18,4)"},
+		{Name: "changed", Type: "Int32"},
+		{Name: "day", Type: "Date", DefaultKind: "MATERIALIZED", DefaultExpression: "toDate(ts)+1"},
+		{Name: "token", Type: "String", DefaultKind: "EPHEMERAL"},
+		{Name: "n", Type: "Nested(a INT, b String)"},
+	}
+	actual := []ColumnModel{
+		{Name: "id", Type: "Int32"},
+		{Name: "note", Type: "String", Nullable: true},
+		{Name: "amount", Type: "Decimal(18, 4)"},
+		{Name: "changed", Type: "Int64"},
+		{Name: "day", Type: "Date", DefaultKind: "MATERIALIZED", DefaultExpression: "toDate(ts) + 1"},
+		{Name: "token", Type: "String", DefaultKind: "EPHEMERAL", DefaultExpression: "defaultValueOfTypeName('String')"},
+		{Name: "n.a", Type: "Array(Int32)"},
+		{Name: "n.b", Type: "Array(String)"},
+		{Name: "added", Type: "String"},
+	}
+	expected := []ColumnModel{
+		{Name: "id", Type: "INT"},
+		{Name: "note", Type: "Nullable(String)"},
+		{Name: "amount", Type: "Decimal(18,4)"},
+		{Name: "changed", Type: "Int64"},
+		{Name: "day", Type: "Date", DefaultKind: "MATERIALIZED", DefaultExpression: "toDate(ts)+1"},
+		{Name: "token", Type: "String", DefaultKind: "EPHEMERAL"},
+		{Name: "n", Type: "Nested(a INT, b String)"},
+		{Name: "added", Type: "String"},
+	}
+
+	result := keepConfiguredColumns(configured, actual)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestKeepConfiguredTTL(t *testing.T) {
+	configured := []TTLRuleModel{
+		{Expression: "d + INTERVAL 1 DAY", RecompressCodec: types.StringValue("ZSTD")},
+	}
+	same := []TTLRuleModel{
+		{Expression: "d + toIntervalDay(1)", RecompressCodec: types.StringValue("ZSTD(1)")},
+	}
+	changed := []TTLRuleModel{
+		{Expression: "d + toIntervalDay(2)", RecompressCodec: types.StringValue("ZSTD(1)")},
+	}
+
+	if result := keepConfiguredTTL(configured, same); !reflect.DeepEqual(result, configured) {
+		t.Errorf("expected %v, got %v", configured, result)
+	}
+	if result := keepConfiguredTTL(configured, changed); !reflect.DeepEqual(result, changed) {
+		t.Errorf("expected %v, got %v", changed, result)
+	}
+}
+
+func TestKeepConfiguredIndexes(t *testing.T) {
+	configured := []IndexModel{
+		{Name: "idx_value", Expression: "value*2", Type: "minmax", Granularity: 1},
+		{Name: "idx_user", Expression: "user_id", Type: "bloom_filter", Granularity: 4},
+	}
+	actual := []IndexModel{
+		{Name: "idx_value", Expression: "value * 2", Type: "minmax", Granularity: 1},
+		{Name: "idx_user", Expression: "user_id", Type: "bloom_filter", Granularity: 8},
+	}
+	expected := []IndexModel{
+		{Name: "idx_value", Expression: "value*2", Type: "minmax", Granularity: 1},
+		{Name: "idx_user", Expression: "user_id", Type: "bloom_filter", Granularity: 8},
+	}
+
+	result := keepConfiguredElements(configured, actual, indexName, sameIndex)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestKeepConfiguredProjections(t *testing.T) {
+	configured := []ProjectionModel{
+		{Name: "by_user", Query: "SELECT * ORDER BY user_id"},
+	}
+	actual := []ProjectionModel{
+		{Name: "by_user", Query: "SELECT *\nORDER BY user_id"},
+		{Name: "totals", Query: "SELECT user_id, count()\nGROUP BY user_id"},
+	}
+	expected := []ProjectionModel{
+		{Name: "by_user", Query: "SELECT * ORDER BY user_id"},
+		{Name: "totals", Query: "SELECT user_id, count()\nGROUP BY user_id"},
+	}
+
+	result := keepConfiguredElements(configured, actual, projectionName, sameProjection)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestKeepConfiguredConstraints(t *testing.T) {
+	configured := []ConstraintModel{
+		{Name: "c_positive", Type: "CHECK", Expression: "amount>=0"},
+		{Name: "c_currency", Type: "CHECK", Expression: "currency != ''"},
+	}
+	actual := []ConstraintModel{
+		{Name: "c_positive", Type: "CHECK", Expression: "amount >= 0"},
+		{Name: "c_currency", Type: "ASSUME", Expression: "currency != ''"},
+	}
+	expected := []ConstraintModel{
+		{Name: "c_positive", Type: "CHECK", Expression: "amount>=0"},
+		{Name: "c_currency", Type: "ASSUME", Expression: "currency != ''"},
+	}
+
+	result := keepConfiguredElements(configured, actual, constraintName, sameConstraint)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestKeepConfiguredKeys(t *testing.T) {
+	ctx := context.Background()
+	configured := TableResourceModel{
+		PartitionBy: types.StringValue("toStartOfInterval(ts, INTERVAL 1 DAY)"),
+		OrderBy:     []string{"`user_id`", "toStartOfHour( ts )"},
+		PrimaryKey:  types.ListValueMust(types.StringType, []attr.Value{types.StringValue("`user_id`")}),
+		SampleBy:    types.StringValue("cityHash64(user_id)"),
+	}
+	model := TableResourceModel{
+		PartitionBy: types.StringValue("toStartOfInterval(ts, toIntervalDay(1))"),
+		OrderBy:     []string{"user_id", "toStartOfHour(ts)"},
+		PrimaryKey:  types.ListValueMust(types.StringType, []attr.Value{types.StringValue("user_id")}),
+		SampleBy:    types.StringValue("cityHash64(id)"),
+	}
+
+	diags := model.keepConfiguredKeys(ctx, configured)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !model.PartitionBy.Equal(configured.PartitionBy) {
+		t.Errorf("expected configured partition key, got %s", model.PartitionBy)
+	}
+	if !reflect.DeepEqual(model.OrderBy, configured.OrderBy) {
+		t.Errorf("expected configured sorting key, got %v", model.OrderBy)
+	}
+	if !model.PrimaryKey.Equal(configured.PrimaryKey) {
+		t.Errorf("expected configured primary key, got %s", model.PrimaryKey)
+	}
+	if model.SampleBy.ValueString() != "cityHash64(id)" {
+		t.Errorf("expected actual sampling key, got %s", model.SampleBy)
+	}
+}
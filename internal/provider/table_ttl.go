@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
+)
+
+type TTLRuleModel struct {
+	Expression      string            `tfsdk:"expression"`
+	ToDisk          types.String      `tfsdk:"to_disk"`
+	ToVolume        types.String      `tfsdk:"to_volume"`
+	RecompressCodec types.String      `tfsdk:"recompress_codec"`
+	Where           types.String      `tfsdk:"where"`
+	GroupBy         []string          `tfsdk:"group_by"`
+	Set             map[string]string `tfsdk:"set"`
+}
+
+var ttlActions = []string{"to_disk", "to_volume", "recompress_codec", "group_by"}
+
+// ttlActionsExcept returns paths of TTL rule actions other than the given one.
+func ttlActionsExcept(action string) []path.Expression {
+	var result []path.Expression
+	for _, a := range ttlActions {
+		if a != action {
+			result = append(result, path.MatchRelative().AtParent().AtName(a))
+		}
+	}
+	return result
+}
+
+// ttlAttribute describes table-level TTL rules.
+func ttlAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Table TTL rules. A rule without `to_disk`, `to_volume`, `recompress_codec` and `group_by` deletes expired rows. " +
+			"See: https://clickhouse.com/docs/en/engines/table-engines/mergetree-family/mergetree#table_engine-mergetree-ttl",
+		Optional:   true,
+		Validators: []validator.List{listvalidator.SizeAtLeast(1)},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"expression": schema.StringAttribute{
+					MarkdownDescription: "TTL expression, e.g. `d + INTERVAL 1 MONTH`",
+					Required:            true,
+					Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+				},
+				"to_disk": schema.StringAttribute{
+					MarkdownDescription: "Disk to move expired parts to (`TO DISK` clause)",
+					Optional:            true,
+					Validators:          []validator.String{stringvalidator.ConflictsWith(ttlActionsExcept("to_disk")...)},
+				},
+				"to_volume": schema.StringAttribute{
+					MarkdownDescription: "Volume to move expired parts to (`TO VOLUME` clause)",
+					Optional:            true,
+					Validators:          []validator.String{stringvalidator.ConflictsWith(ttlActionsExcept("to_volume")...)},
+				},
+				"recompress_codec": schema.StringAttribute{
+					MarkdownDescription: "Codecs to recompress expired parts with, without the `CODEC` keyword, e.g. `ZSTD(17)`",
+					Optional:            true,
+					Validators:          []validator.String{stringvalidator.ConflictsWith(ttlActionsExcept("recompress_codec")...)},
+				},
+				"where": schema.StringAttribute{
+					MarkdownDescription: "Condition for rows to delete or aggregate (`WHERE` clause)",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.ConflictsWith(
+							path.MatchRelative().AtParent().AtName("to_disk"),
+							path.MatchRelative().AtParent().AtName("to_volume"),
+							path.MatchRelative().AtParent().AtName("recompress_codec"),
+						),
+					},
+				},
+				"group_by": schema.ListAttribute{
+					MarkdownDescription: "Prefix of the primary key to aggregate expired rows by (`GROUP BY` clause)",
+					Optional:            true,
+					ElementType:         types.StringType,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+						listvalidator.ConflictsWith(ttlActionsExcept("group_by")...),
+					},
+				},
+				"set": schema.MapAttribute{
+					MarkdownDescription: "Aggregate expressions for columns of aggregated rows (`SET` clause), e.g. `{ x = \"max(x)\" }`",
+					Optional:            true,
+					ElementType:         types.StringType,
+					Validators: []validator.Map{
+						mapvalidator.SizeAtLeast(1),
+						mapvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("group_by")),
+					},
+				},
+			},
+		},
+	}
+}
+
+func toChClientTTL(rules []TTLRuleModel) []chclient.ClickHouseTTLRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]chclient.ClickHouseTTLRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, chclient.ClickHouseTTLRule{
+			Expression:      rule.Expression,
+			ToDisk:          rule.ToDisk.ValueString(),
+			ToVolume:        rule.ToVolume.ValueString(),
+			RecompressCodec: rule.RecompressCodec.ValueString(),
+			Where:           rule.Where.ValueString(),
+			GroupBy:         rule.GroupBy,
+			Set:             rule.Set,
+		})
+	}
+	return result
+}
+
+func fromChClientTTL(rules []chclient.ClickHouseTTLRule) []TTLRuleModel {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]TTLRuleModel, 0, len(rules))
+	for _, rule := range rules {
+		model := TTLRuleModel{
+			Expression:      rule.Expression,
+			ToDisk:          stringOrNull(rule.ToDisk),
+			ToVolume:        stringOrNull(rule.ToVolume),
+			RecompressCodec: stringOrNull(rule.RecompressCodec),
+			Where:           stringOrNull(rule.Where),
+		}
+		if len(rule.GroupBy) > 0 {
+			model.GroupBy = rule.GroupBy
+		}
+		if len(rule.Set) > 0 {
+			model.Set = rule.Set
+		}
+		result = append(result, model)
+	}
+	return result
+}
+
+// keepConfiguredTTL keeps TTL rules as they are written in the configuration
+// if ClickHouse reports the same rules in another form, e.g. `toIntervalDay(1)` for `INTERVAL 1 DAY`.
+func keepConfiguredTTL(configured, actual []TTLRuleModel) []TTLRuleModel {
+	if chclient.SameTTL(toChClientTTL(configured), toChClientTTL(actual)) {
+		return configured
+	}
+	return actual
+}
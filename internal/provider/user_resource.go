@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"net"
+	"reflect"
 	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -20,6 +24,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
 
 func NewUserResource() resource.Resource {
 	return &UserResource{}
@@ -35,9 +40,60 @@ type sha256hash struct {
 	Salt string `tfsdk:"salt"`
 }
 
+type ldapIdentification struct {
+	Server string `tfsdk:"server"`
+}
+
+type kerberosIdentification struct {
+	Realm *string `tfsdk:"realm"`
+}
+
+type sslCertificateIdentification struct {
+	CommonNames     []string `tfsdk:"common_names"`
+	SubjectAltNames []string `tfsdk:"subject_alt_names"`
+}
+
+type sshKey struct {
+	Key  string `tfsdk:"key"`
+	Type string `tfsdk:"type"`
+}
+
+type httpIdentification struct {
+	Server string  `tfsdk:"server"`
+	Scheme *string `tfsdk:"scheme"`
+}
+
 type identifiedWith struct {
-	Sha256Hash     *sha256hash `tfsdk:"sha256_hash"`
-	Sha256Password *string     `tfsdk:"sha256_password"`
+	Sha256Hash         *sha256hash                   `tfsdk:"sha256_hash"`
+	Sha256Password     *string                       `tfsdk:"sha256_password"`
+	PlaintextPassword  *string                       `tfsdk:"plaintext_password"`
+	DoubleSha1Password *string                       `tfsdk:"double_sha1_password"`
+	DoubleSha1Hash     *string                       `tfsdk:"double_sha1_hash"`
+	BcryptPassword     *string                       `tfsdk:"bcrypt_password"`
+	BcryptHash         *string                       `tfsdk:"bcrypt_hash"`
+	NoPassword         *bool                         `tfsdk:"no_password"`
+	LDAP               *ldapIdentification           `tfsdk:"ldap"`
+	Kerberos           *kerberosIdentification       `tfsdk:"kerberos"`
+	SSLCertificate     *sslCertificateIdentification `tfsdk:"ssl_certificate"`
+	SSHKey             []sshKey                      `tfsdk:"ssh_key"`
+	HTTP               *httpIdentification           `tfsdk:"http"`
+}
+
+// identificationMethods lists attributes of identified_with. Exactly one of them should be set.
+var identificationMethods = []string{
+	"sha256_hash",
+	"sha256_password",
+	"plaintext_password",
+	"double_sha1_password",
+	"double_sha1_hash",
+	"bcrypt_password",
+	"bcrypt_hash",
+	"no_password",
+	"ldap",
+	"kerberos",
+	"ssl_certificate",
+	"ssh_key",
+	"http",
 }
 
 type userAllowedHosts struct {
@@ -48,28 +104,233 @@ type userAllowedHosts struct {
 }
 
 type UserResourceModel struct {
-	ID              types.String      `tfsdk:"id"`
-	Name            string            `tfsdk:"name"`
-	IdentifiedWith  identifiedWith    `tfsdk:"identified_with"`
-	Hosts           *userAllowedHosts `tfsdk:"hosts"`
-	DefaultDatabase types.String      `tfsdk:"default_database"`
+	ID                    types.String      `tfsdk:"id"`
+	Name                  string            `tfsdk:"name"`
+	IdentifiedWith        *identifiedWith   `tfsdk:"identified_with"`
+	AuthenticationMethods []identifiedWith  `tfsdk:"authentication_methods"`
+	Hosts                 *userAllowedHosts `tfsdk:"hosts"`
+	DefaultDatabase       types.String      `tfsdk:"default_database"`
+	Cluster               types.String      `tfsdk:"cluster"`
 }
 
-func (user UserResourceModel) ToClickHouseClientUser() (chclient.ClickHouseUser, error) {
-	var auth chclient.ClickHouseUserAuthType
-	if user.IdentifiedWith.Sha256Hash != nil {
-		auth = chclient.Sha256HashAuth{
-			Hash: user.IdentifiedWith.Sha256Hash.Hash,
-			Salt: user.IdentifiedWith.Sha256Hash.Salt,
+func (iw identifiedWith) toAuth() (chclient.ClickHouseUserAuthType, error) {
+	switch {
+	case iw.Sha256Hash != nil:
+		return chclient.Sha256HashAuth{
+			Hash: iw.Sha256Hash.Hash,
+			Salt: iw.Sha256Hash.Salt,
+		}, nil
+	case iw.Sha256Password != nil:
+		return chclient.Sha256PasswordAuth{Password: *iw.Sha256Password}, nil
+	case iw.PlaintextPassword != nil:
+		return chclient.PlaintextPasswordAuth{Password: *iw.PlaintextPassword}, nil
+	case iw.DoubleSha1Password != nil:
+		return chclient.DoubleSha1PasswordAuth{Password: *iw.DoubleSha1Password}, nil
+	case iw.DoubleSha1Hash != nil:
+		return chclient.DoubleSha1HashAuth{Hash: *iw.DoubleSha1Hash}, nil
+	case iw.BcryptPassword != nil:
+		return chclient.BcryptPasswordAuth{Password: *iw.BcryptPassword}, nil
+	case iw.BcryptHash != nil:
+		return chclient.BcryptHashAuth{Hash: *iw.BcryptHash}, nil
+	case iw.NoPassword != nil:
+		return chclient.NoPasswordAuth{}, nil
+	case iw.LDAP != nil:
+		return chclient.LDAPAuth{Server: iw.LDAP.Server}, nil
+	case iw.Kerberos != nil:
+		return chclient.KerberosAuth{Realm: stringFromPointer(iw.Kerberos.Realm)}, nil
+	case iw.SSLCertificate != nil:
+		return chclient.SSLCertificateAuth{
+			CommonNames:     iw.SSLCertificate.CommonNames,
+			SubjectAltNames: iw.SSLCertificate.SubjectAltNames,
+		}, nil
+	case iw.SSHKey != nil:
+		keys := make([]chclient.SSHKey, 0, len(iw.SSHKey))
+		for _, key := range iw.SSHKey {
+			keys = append(keys, chclient.SSHKey{Key: key.Key, Type: key.Type})
 		}
-	} else if user.IdentifiedWith.Sha256Password != nil {
-		auth = chclient.Sha256PasswordAuth{
-			Password: *user.IdentifiedWith.Sha256Password,
+		return chclient.SSHKeyAuth{Keys: keys}, nil
+	case iw.HTTP != nil:
+		return chclient.HTTPAuth{Server: iw.HTTP.Server, Scheme: stringFromPointer(iw.HTTP.Scheme)}, nil
+	}
+
+	return nil, errors.New("exactly one identification method should be set in IdentifiedWith")
+}
+
+// withServerAuth reconciles identification from the state with the one ClickHouse reports.
+// ClickHouse does not expose secrets, so the state is kept while the method is the same.
+// Non-secret parameters (LDAP server, Kerberos realm, certificate names, etc.) are taken from the server.
+// If the method was changed outside Terraform, the identification is reset so that the plan shows the change.
+func (iw identifiedWith) withServerAuth(auth chclient.ClickHouseUserAuthType) identifiedWith {
+	stateAuth, err := iw.toAuth()
+	if err != nil || stateAuth.Method() != auth.Method() {
+		return identifiedWith{}
+	}
+
+	switch a := auth.(type) {
+	case chclient.Sha256HashAuth:
+		if iw.Sha256Hash != nil {
+			iw.Sha256Hash = &sha256hash{Hash: iw.Sha256Hash.Hash, Salt: a.Salt}
 		}
-	} else {
-		return chclient.ClickHouseUser{}, errors.New(
-			"either IdentifiedWith.Sha256Hash or IdentifiedWith.Sha256Password should be non-nil",
-		)
+	case chclient.LDAPAuth:
+		iw.LDAP = &ldapIdentification{Server: a.Server}
+	case chclient.KerberosAuth:
+		iw.Kerberos = &kerberosIdentification{Realm: stringPointerOrNil(a.Realm)}
+	case chclient.SSLCertificateAuth:
+		iw.SSLCertificate = &sslCertificateIdentification{
+			CommonNames:     stringsOrNil(a.CommonNames),
+			SubjectAltNames: stringsOrNil(a.SubjectAltNames),
+		}
+	case chclient.HTTPAuth:
+		iw.HTTP = &httpIdentification{Server: a.Server, Scheme: iw.HTTP.Scheme}
+		if a.Scheme != "" {
+			iw.HTTP.Scheme = &a.Scheme
+		}
+	}
+
+	return iw
+}
+
+// identifiedWithFromAuth builds identification of an imported user. Secrets are left empty.
+func identifiedWithFromAuth(auth chclient.ClickHouseUserAuthType) identifiedWith {
+	empty := ""
+	switch a := auth.(type) {
+	case chclient.Sha256HashAuth:
+		return identifiedWith{Sha256Hash: &sha256hash{Salt: a.Salt}}
+	case chclient.Sha256PasswordAuth:
+		return identifiedWith{Sha256Hash: &sha256hash{}}
+	case chclient.PlaintextPasswordAuth:
+		return identifiedWith{PlaintextPassword: &empty}
+	case chclient.DoubleSha1PasswordAuth:
+		return identifiedWith{DoubleSha1Hash: &empty}
+	case chclient.BcryptPasswordAuth:
+		return identifiedWith{BcryptHash: &empty}
+	case chclient.NoPasswordAuth:
+		noPassword := true
+		return identifiedWith{NoPassword: &noPassword}
+	case chclient.LDAPAuth:
+		return identifiedWith{LDAP: &ldapIdentification{Server: a.Server}}
+	case chclient.KerberosAuth:
+		return identifiedWith{Kerberos: &kerberosIdentification{Realm: stringPointerOrNil(a.Realm)}}
+	case chclient.SSLCertificateAuth:
+		return identifiedWith{SSLCertificate: &sslCertificateIdentification{
+			CommonNames:     stringsOrNil(a.CommonNames),
+			SubjectAltNames: stringsOrNil(a.SubjectAltNames),
+		}}
+	case chclient.SSHKeyAuth:
+		return identifiedWith{SSHKey: []sshKey{}}
+	case chclient.HTTPAuth:
+		return identifiedWith{HTTP: &httpIdentification{Server: a.Server, Scheme: stringPointerOrNil(a.Scheme)}}
+	}
+
+	return identifiedWith{}
+}
+
+func stringFromPointer(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func stringPointerOrNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func stringsOrNil(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func identificationMethodPaths() path.Expressions {
+	paths := make(path.Expressions, 0, len(identificationMethods))
+	for _, method := range identificationMethods {
+		paths = append(paths, path.MatchRelative().AtParent().AtName(method))
+	}
+	return paths
+}
+
+// authMethods returns identification methods set either in identified_with or in authentication_methods.
+func (user UserResourceModel) authMethods() ([]chclient.ClickHouseUserAuthType, error) {
+	if user.IdentifiedWith != nil {
+		auth, err := user.IdentifiedWith.toAuth()
+		if err != nil {
+			return nil, err
+		}
+		return []chclient.ClickHouseUserAuthType{auth}, nil
+	}
+
+	methods := make([]chclient.ClickHouseUserAuthType, 0, len(user.AuthenticationMethods))
+	for _, iw := range user.AuthenticationMethods {
+		auth, err := iw.toAuth()
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, auth)
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("either IdentifiedWith or AuthenticationMethods should be set")
+	}
+
+	return methods, nil
+}
+
+// withServerAuth reconciles identification methods from the state with the ones ClickHouse reports.
+func (user UserResourceModel) withServerAuth(auth []chclient.ClickHouseUserAuthType) UserResourceModel {
+	if user.IdentifiedWith != nil {
+		if len(auth) != 1 {
+			user.IdentifiedWith = &identifiedWith{}
+			return user
+		}
+		iw := user.IdentifiedWith.withServerAuth(auth[0])
+		user.IdentifiedWith = &iw
+		return user
+	}
+
+	if len(auth) != len(user.AuthenticationMethods) {
+		user.AuthenticationMethods = identifiedWithListFromAuth(auth)
+		return user
+	}
+
+	methods := make([]identifiedWith, 0, len(auth))
+	for i, a := range auth {
+		methods = append(methods, user.AuthenticationMethods[i].withServerAuth(a))
+	}
+	user.AuthenticationMethods = methods
+
+	return user
+}
+
+func identifiedWithListFromAuth(auth []chclient.ClickHouseUserAuthType) []identifiedWith {
+	methods := make([]identifiedWith, 0, len(auth))
+	for _, a := range auth {
+		methods = append(methods, identifiedWithFromAuth(a))
+	}
+	return methods
+}
+
+// addedAuthMethods returns methods appended to the end of the list if the plan keeps all methods from the state.
+// Such methods can be added without resetting the existing ones.
+func addedAuthMethods(state, plan []chclient.ClickHouseUserAuthType) ([]chclient.ClickHouseUserAuthType, bool) {
+	if len(plan) <= len(state) {
+		return nil, false
+	}
+	for i := range state {
+		if !reflect.DeepEqual(state[i], plan[i]) {
+			return nil, false
+		}
+	}
+	return plan[len(state):], true
+}
+
+func (user UserResourceModel) ToClickHouseClientUser() (chclient.ClickHouseUser, error) {
+	auth, err := user.authMethods()
+	if err != nil {
+		return chclient.ClickHouseUser{}, err
 	}
 
 	var hosts *chclient.ClickHouseUserHosts
@@ -125,47 +386,24 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			},
 			"identified_with": schema.SingleNestedAttribute{
 				MarkdownDescription: "User identification method. " +
-					"See: https://clickhouse.com/docs/en/sql-reference/statements/create/user#identification ." +
-					"Supported methods: `sha256_hash` and `sha256_password`. Only one of them should be set",
-				Required: true,
-				Attributes: map[string]schema.Attribute{
-					"sha256_hash": schema.SingleNestedAttribute{
-						MarkdownDescription: "Settings for identification `sha256_hash` identification",
-						Optional:            true,
-						Attributes: map[string]schema.Attribute{
-							"hash": schema.StringAttribute{
-								MarkdownDescription: "SHA256 of the password",
-								Required:            true,
-								Sensitive:           true,
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(
-										regexp.MustCompile("[a-fA-F0-9]{64}"),
-										`SHA256 hash should contain 64 hexadecimal digits (regexp: "[a-f0-9][a-fA-F0-9]{64}")`,
-									),
-								},
-							},
-							"salt": schema.StringAttribute{
-								MarkdownDescription: "Salt for adding to the password before SHA256-hashing",
-								Optional:            true,
-								Sensitive:           true,
-								Computed:            true,
-								Default:             stringdefault.StaticString(""),
-							},
-						},
-					},
-					"sha256_password": schema.StringAttribute{
-						MarkdownDescription: "Password for `sha256_password` identification. It is better to use " +
-							"`sha256_hash` instead of `sha256_password` in order to avoid storing passwords " +
-							"in terraform state",
-						Optional:  true,
-						Sensitive: true,
-						Validators: []validator.String{
-							stringvalidator.ExactlyOneOf(path.Expressions{
-								path.MatchRoot("identified_with").AtName("sha256_hash"),
-								path.MatchRoot("identified_with").AtName("sha256_password"),
-							}...),
-						},
-					},
+					"See: https://clickhouse.com/docs/en/sql-reference/statements/create/user#identification . " +
+					"Exactly one method should be set. Conflicts with `authentication_methods`",
+				Optional:   true,
+				Attributes: identificationAttributes(),
+				Validators: []validator.Object{
+					objectvalidator.ExactlyOneOf(path.MatchRoot("authentication_methods")),
+				},
+			},
+			"authentication_methods": schema.ListNestedAttribute{
+				MarkdownDescription: "Several identification methods, the user can log in with any of them. " +
+					"Each element has the same attributes as `identified_with`. Appending a method to the end of the list " +
+					"adds it with `ADD IDENTIFIED WITH` keeping the existing ones, so credentials can be rotated " +
+					"without downtime: add a new method, roll out clients, then remove the old one. " +
+					"Requires ClickHouse 24.9 or newer. Conflicts with `identified_with`",
+				Optional:   true,
+				Validators: []validator.List{listvalidator.SizeAtLeast(1)},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: identificationAttributes(),
 				},
 			},
 			"hosts": schema.SingleNestedAttribute{
@@ -214,6 +452,7 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 				Default:             stringdefault.StaticString(""),
 			},
+			"cluster": clusterAttribute(),
 		},
 	}
 }
@@ -226,6 +465,40 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
+// ModifyPlan reports identification methods which are not supported by the ClickHouse server.
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var identifiedWithSSHKey types.List
+	var authenticationMethods types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("identified_with").AtName("ssh_key"), &identifiedWithSSHKey)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("authentication_methods"), &authenticationMethods)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !identifiedWithSSHKey.IsNull() && !identifiedWithSSHKey.IsUnknown() {
+		requireCapability(&resp.Diagnostics, r.client, chclient.CapabilitySSHKeyAuth, path.Root("identified_with").AtName("ssh_key"))
+	}
+	if authenticationMethods.IsNull() {
+		return
+	}
+
+	requireCapability(&resp.Diagnostics, r.client, chclient.CapabilityMultipleAuthMethods, path.Root("authentication_methods"))
+	for i, element := range authenticationMethods.Elements() {
+		method, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+		if sshKey, ok := method.Attributes()["ssh_key"]; ok && !sshKey.IsNull() {
+			attribute := path.Root("authentication_methods").AtListIndex(i).AtName("ssh_key")
+			requireCapability(&resp.Diagnostics, r.client, chclient.CapabilitySSHKeyAuth, attribute)
+		}
+	}
+}
+
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var userModel UserResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &userModel)...)
@@ -244,12 +517,9 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	err = r.client.CreateUser(ctx, user)
+	err = clusterClient(r.client, userModel.Cluster).CreateUser(ctx, user)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot create user",
-			"Create user query failed: "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot create user", err)
 		return
 	}
 
@@ -264,12 +534,13 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	receivedUser, err := r.client.GetUser(ctx, model.Name)
+	receivedUser, err := clusterClient(r.client, model.Cluster).GetUser(ctx, model.Name)
 	if err != nil {
 		handleNotFoundError(ctx, err, resp, "user", model.Name)
 		return
 	}
 	model.Name = receivedUser.Name
+	*model = model.withServerAuth(receivedUser.Auth)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
@@ -294,15 +565,28 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	err = r.client.AlterUser(ctx, stateUser.Name, user)
+	// The state may have no valid methods if they were changed outside Terraform, then all methods are reset.
+	stateAuth, _ := stateUser.authMethods()
+	addedAuth, onlyAdded := addedAuthMethods(stateAuth, user.Auth)
+	if onlyAdded {
+		user.Auth = nil
+	}
+
+	client := clusterClient(r.client, planUser.Cluster)
+	err = client.AlterUser(ctx, stateUser.Name, user)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot alter user",
-			err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot alter user", err)
 		return
 	}
 
+	if onlyAdded {
+		err = client.AddUserAuth(ctx, planUser.Name, addedAuth)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Cannot add authentication methods to user", err)
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &planUser)...)
 }
 
@@ -310,12 +594,9 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	var user *UserResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &user)...)
 
-	err := r.client.DropUser(ctx, user.Name)
+	err := clusterClient(r.client, user.Cluster).DropUser(ctx, user.Name)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot delete user",
-			err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot delete user", err)
 		return
 	}
 
@@ -327,10 +608,7 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	user, err := r.client.GetUser(ctx, req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot alter user",
-			err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot import user", err)
 		return
 	}
 	var hosts *userAllowedHosts
@@ -349,14 +627,173 @@ func (r *UserResource) ImportState(ctx context.Context, req resource.ImportState
 		}
 	}
 
-	emptyPassword := ""
 	stateUser := UserResourceModel{
 		ID:              types.StringValue(user.Name),
 		Name:            user.Name,
-		IdentifiedWith:  identifiedWith{Sha256Password: &emptyPassword},
 		Hosts:           hosts,
 		DefaultDatabase: types.StringValue(string(user.DefaultDatabase)),
 	}
+	if len(user.Auth) == 1 {
+		iw := identifiedWithFromAuth(user.Auth[0])
+		stateUser.IdentifiedWith = &iw
+	} else {
+		stateUser.AuthenticationMethods = identifiedWithListFromAuth(user.Auth)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &stateUser)...)
 }
+
+// identificationAttributes returns attributes describing a single identification method.
+func identificationAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"sha256_hash": schema.SingleNestedAttribute{
+			MarkdownDescription: "Settings for identification `sha256_hash` identification",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"hash": schema.StringAttribute{
+					MarkdownDescription: "SHA256 of the password",
+					Required:            true,
+					Sensitive:           true,
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(
+							regexp.MustCompile("[a-fA-F0-9]{64}"),
+							`SHA256 hash should contain 64 hexadecimal digits (regexp: "[a-f0-9][a-fA-F0-9]{64}")`,
+						),
+					},
+				},
+				"salt": schema.StringAttribute{
+					MarkdownDescription: "Salt for adding to the password before SHA256-hashing",
+					Optional:            true,
+					Sensitive:           true,
+					Computed:            true,
+					Default:             stringdefault.StaticString(""),
+				},
+			},
+		},
+		"sha256_password": schema.StringAttribute{
+			MarkdownDescription: "Password for `sha256_password` identification. It is better to use " +
+				"`sha256_hash` instead of `sha256_password` in order to avoid storing passwords " +
+				"in terraform state",
+			Optional:  true,
+			Sensitive: true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(identificationMethodPaths()...),
+			},
+		},
+		"plaintext_password": schema.StringAttribute{
+			MarkdownDescription: "Password for `plaintext_password` identification. " +
+				"ClickHouse stores such passwords unencrypted",
+			Optional:  true,
+			Sensitive: true,
+		},
+		"double_sha1_password": schema.StringAttribute{
+			MarkdownDescription: "Password for `double_sha1_password` identification. It is better to use " +
+				"`double_sha1_hash` in order to avoid storing passwords in terraform state",
+			Optional:  true,
+			Sensitive: true,
+		},
+		"double_sha1_hash": schema.StringAttribute{
+			MarkdownDescription: "SHA1 of SHA1 of the password for `double_sha1_password` identification",
+			Optional:            true,
+			Sensitive:           true,
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(
+					regexp.MustCompile("^[a-fA-F0-9]{40}$"),
+					"Double SHA1 hash should contain 40 hexadecimal digits",
+				),
+			},
+		},
+		"bcrypt_password": schema.StringAttribute{
+			MarkdownDescription: "Password for `bcrypt_password` identification. It is better to use " +
+				"`bcrypt_hash` in order to avoid storing passwords in terraform state",
+			Optional:  true,
+			Sensitive: true,
+		},
+		"bcrypt_hash": schema.StringAttribute{
+			MarkdownDescription: "Bcrypt hash of the password for `bcrypt_password` identification",
+			Optional:            true,
+			Sensitive:           true,
+		},
+		"no_password": schema.BoolAttribute{
+			MarkdownDescription: "`no_password` identification. Can only be set to `true`",
+			Optional:            true,
+			Validators:          []validator.Bool{boolvalidator.Equals(true)},
+		},
+		"ldap": schema.SingleNestedAttribute{
+			MarkdownDescription: "`ldap` identification against an LDAP server from the server configuration",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"server": schema.StringAttribute{
+					MarkdownDescription: "Name of the LDAP server in the `ldap_servers` section of the server configuration",
+					Required:            true,
+				},
+			},
+		},
+		"kerberos": schema.SingleNestedAttribute{
+			MarkdownDescription: "`kerberos` identification",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"realm": schema.StringAttribute{
+					MarkdownDescription: "Kerberos realm. If unset, any realm is allowed",
+					Optional:            true,
+				},
+			},
+		},
+		"ssl_certificate": schema.SingleNestedAttribute{
+			MarkdownDescription: "`ssl_certificate` identification by a client TLS certificate",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"common_names": schema.SetAttribute{
+					MarkdownDescription: "Allowed certificate common names (`CN`)",
+					Optional:            true,
+					ElementType:         types.StringType,
+					Validators: []validator.Set{
+						setvalidator.SizeAtLeast(1),
+						setvalidator.ExactlyOneOf(
+							path.MatchRelative().AtParent().AtName("common_names"),
+							path.MatchRelative().AtParent().AtName("subject_alt_names"),
+						),
+					},
+				},
+				"subject_alt_names": schema.SetAttribute{
+					MarkdownDescription: "Allowed certificate subject alternative names (`SAN`), " +
+						"e.g. `DNS:host.example.com` or `URI:spiffe://example.com/service`",
+					Optional:    true,
+					ElementType: types.StringType,
+					Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+				},
+			},
+		},
+		"ssh_key": schema.ListNestedAttribute{
+			MarkdownDescription: "`ssh_key` identification. The user can log in with any of the keys. Requires ClickHouse 23.9 or newer",
+			Optional:            true,
+			Validators:          []validator.List{listvalidator.SizeAtLeast(1)},
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"key": schema.StringAttribute{
+						MarkdownDescription: "Base64-encoded public key",
+						Required:            true,
+					},
+					"type": schema.StringAttribute{
+						MarkdownDescription: "Key type, e.g. `ssh-ed25519` or `ssh-rsa`",
+						Required:            true,
+					},
+				},
+			},
+		},
+		"http": schema.SingleNestedAttribute{
+			MarkdownDescription: "`http` identification against an HTTP authentication server from the server configuration",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"server": schema.StringAttribute{
+					MarkdownDescription: "Name of the server in the `http_authentication_servers` section of the server configuration",
+					Required:            true,
+				},
+				"scheme": schema.StringAttribute{
+					MarkdownDescription: "Authentication scheme, e.g. `basic`",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
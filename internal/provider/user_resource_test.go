@@ -2,9 +2,13 @@ package provider
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
 )
 
 func TestAccUserResource(t *testing.T) {
@@ -26,12 +30,27 @@ func TestAccUserResource(t *testing.T) {
 				),
 			},
 			{
-				Config:                  chUserSha256HashPasswordResource("myuser"),
-				ResourceName:            "clickhouse_user.test",
-				ImportState:             true,
-				ImportStateId:           "myuser",
-				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"identified_with"},
+				Config:            chUserSha256HashPasswordResource("myuser"),
+				ResourceName:      "clickhouse_user.test",
+				ImportState:       true,
+				ImportStateId:     "myuser",
+				ImportStateVerify: true,
+				// ClickHouse does not expose password hashes.
+				ImportStateVerifyIgnore: []string{"identified_with.sha256_hash.hash"},
+			},
+			{
+				PreConfig: func() {
+					testAccExec(t, "ALTER USER myuser IDENTIFIED WITH plaintext_password BY 'qwerty'")
+				},
+				Config:             chUserSha256HashPasswordResource("myuser"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: chUserSha256HashPasswordResource("myuser"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_user.test", "identified_with.sha256_hash.hash", "2b915881367d1bd1ed3ab58b9fccc69fe4e3ee5492ab654ebd56c989ea6bd571"),
+				),
 			},
 		},
 	})
@@ -59,3 +78,156 @@ resource "clickhouse_user" "test" {
 `, name)
 	return providerConfig + resources
 }
+
+func TestAccUserResourceIdentificationMethods(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: chUserIdentifiedWithResource("myuser", `double_sha1_hash = "ed8e2c3fa4aa5d84ad5e4e17a7a0dbdd8f0b5e0f"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_user.test", "identified_with.double_sha1_hash", "ed8e2c3fa4aa5d84ad5e4e17a7a0dbdd8f0b5e0f"),
+				),
+			},
+			{
+				Config: chUserIdentifiedWithResource("myuser", `no_password = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_user.test", "identified_with.no_password", "true"),
+					resource.TestCheckNoResourceAttr("clickhouse_user.test", "identified_with.double_sha1_hash"),
+				),
+			},
+			{
+				Config: chUserIdentifiedWithResource("myuser", `ssl_certificate = { common_names = ["myuser.example.com"] }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_user.test", "identified_with.ssl_certificate.common_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr("clickhouse_user.test", "identified_with.ssl_certificate.common_names.*", "myuser.example.com"),
+				),
+			},
+			{
+				ResourceName:      "clickhouse_user.test",
+				ImportState:       true,
+				ImportStateId:     "myuser",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func chUserIdentifiedWithResource(name, identifiedWith string) string {
+	providerConfig := chProviderConfig()
+	resources := fmt.Sprintf(`
+resource "clickhouse_user" "test" {
+  name = %[1]q
+
+  identified_with = {
+    %[2]s
+  }
+}
+`, name, identifiedWith)
+	return providerConfig + resources
+}
+
+func TestAccUserResourceAuthenticationMethodsRotation(t *testing.T) {
+	oldPassword := `{ sha256_password = "old_password" }`
+	newPassword := `{ sha256_password = "new_password" }`
+
+	if !testAccSupports(t, chclient.CapabilityMultipleAuthMethods) {
+		resource.Test(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:      chUserAuthenticationMethodsResource("myuser", oldPassword),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`Feature is not supported by ClickHouse server`),
+				},
+			},
+		})
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: chUserAuthenticationMethodsResource("myuser", oldPassword),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_user.test", "authentication_methods.#", "1"),
+				),
+			},
+			{
+				Config: chUserAuthenticationMethodsResource("myuser", oldPassword, newPassword),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_user.test", "authentication_methods.#", "2"),
+					resource.TestCheckResourceAttr("clickhouse_user.test", "authentication_methods.1.sha256_password", "new_password"),
+				),
+			},
+			{
+				Config: chUserAuthenticationMethodsResource("myuser", newPassword),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouse_user.test", "authentication_methods.#", "1"),
+					resource.TestCheckResourceAttr("clickhouse_user.test", "authentication_methods.0.sha256_password", "new_password"),
+				),
+			},
+		},
+	})
+}
+
+func chUserAuthenticationMethodsResource(name string, methods ...string) string {
+	providerConfig := chProviderConfig()
+	resources := fmt.Sprintf(`
+resource "clickhouse_user" "test" {
+  name = %[1]q
+
+  authentication_methods = [%[2]s]
+}
+`, name, strings.Join(methods, ", "))
+	return providerConfig + resources
+}
+
+func TestAddedAuthMethods(t *testing.T) {
+	oldPassword := chclient.Sha256PasswordAuth{Password: "old"}
+	newPassword := chclient.Sha256PasswordAuth{Password: "new"}
+
+	testCases := []struct {
+		name          string
+		state         []chclient.ClickHouseUserAuthType
+		plan          []chclient.ClickHouseUserAuthType
+		expectedAdded []chclient.ClickHouseUserAuthType
+		expectedOk    bool
+	}{
+		{
+			name:          "Method appended",
+			state:         []chclient.ClickHouseUserAuthType{oldPassword},
+			plan:          []chclient.ClickHouseUserAuthType{oldPassword, newPassword},
+			expectedAdded: []chclient.ClickHouseUserAuthType{newPassword},
+			expectedOk:    true,
+		},
+		{
+			name:  "Method removed",
+			state: []chclient.ClickHouseUserAuthType{oldPassword, newPassword},
+			plan:  []chclient.ClickHouseUserAuthType{newPassword},
+		},
+		{
+			name:  "Method prepended",
+			state: []chclient.ClickHouseUserAuthType{oldPassword},
+			plan:  []chclient.ClickHouseUserAuthType{newPassword, oldPassword},
+		},
+		{
+			name:  "Methods unchanged",
+			state: []chclient.ClickHouseUserAuthType{oldPassword},
+			plan:  []chclient.ClickHouseUserAuthType{oldPassword},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			added, ok := addedAuthMethods(tc.state, tc.plan)
+			if ok != tc.expectedOk || !reflect.DeepEqual(added, tc.expectedAdded) {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tc.expectedAdded, tc.expectedOk, added, ok)
+			}
+		})
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vegassor/terraform-provider-clickhouse/internal/chclient"
 	"regexp"
+	"time"
 )
 
 type IdentifiedWithValidator struct{}
@@ -59,3 +61,50 @@ func (v grantEntityValidator) ValidateString(ctx context.Context, request valida
 	)
 	regexValidator.ValidateString(ctx, request, response)
 }
+
+// columnTypeValidator checks that a value is a valid ClickHouse data type.
+type columnTypeValidator struct{}
+
+func (v columnTypeValidator) Description(context.Context) string {
+	return "Value should be a valid ClickHouse data type, e.g. `String`, `Decimal(18, 4)` or `Array(Nullable(String))`"
+}
+
+func (v columnTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v columnTypeValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := chclient.ParseColumnType(request.ConfigValue.ValueString()); err != nil {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid column type", err.Error())
+	}
+}
+
+// durationValidator checks that a value is a Go duration, e.g. `500ms` or `1m30s`.
+type durationValidator struct{}
+
+func (v durationValidator) Description(context.Context) string {
+	return "Value should be a duration, e.g. `500ms` or `1m30s`"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(request.ConfigValue.ValueString())
+	if err != nil {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid duration", err.Error())
+		return
+	}
+	if duration < 0 {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid duration", "Duration should not be negative")
+	}
+}
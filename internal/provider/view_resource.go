@@ -30,6 +30,7 @@ type ViewResourceModel struct {
 	Name     string       `tfsdk:"name"`
 	FullName types.String `tfsdk:"full_name"`
 	Query    string       `tfsdk:"query"`
+	Cluster  types.String `tfsdk:"cluster"`
 }
 
 func (r *ViewResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,6 +66,7 @@ func (r *ViewResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "View definition query. It should be a valid SELECT statement.",
 				Required:            true,
 			},
+			"cluster": clusterAttribute(),
 		},
 	}
 }
@@ -90,8 +92,8 @@ func (r *ViewResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Query:    model.Query,
 	}
 
-	if err := r.client.CreateView(ctx, view, false); err != nil {
-		resp.Diagnostics.AddError("Failed to create a view", err.Error())
+	if err := clusterClient(r.client, model.Cluster).CreateView(ctx, view, false); err != nil {
+		addClientError(&resp.Diagnostics, "Failed to create a view", err)
 		return
 	}
 
@@ -106,7 +108,7 @@ func (r *ViewResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	view, err := r.client.GetTable(ctx, model.Database, model.Name)
+	view, err := clusterClient(r.client, model.Cluster).GetTable(ctx, model.Database, model.Name)
 	if err != nil {
 		handleNotFoundError(ctx, err, resp, "view", model.FullName.ValueString())
 		return
@@ -142,11 +144,8 @@ func (r *ViewResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		Query:    planModel.Query,
 	}
 
-	if err := r.client.CreateView(ctx, view, true); err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot replace view",
-			err.Error(),
-		)
+	if err := clusterClient(r.client, planModel.Cluster).CreateView(ctx, view, true); err != nil {
+		addClientError(&resp.Diagnostics, "Cannot replace view", err)
 		return
 	}
 
@@ -158,12 +157,9 @@ func (r *ViewResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
 
 	view := chclient.ClickHouseTable{Database: model.Database, Name: model.Name}
-	err := r.client.DropTable(ctx, view, false)
+	err := clusterClient(r.client, model.Cluster).DropTable(ctx, view, false)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cannot drop view",
-			err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Cannot drop view", err)
 		return
 	}
 }